@@ -7,11 +7,12 @@ import (
 
 func TestHandleCommand(t *testing.T) {
 	tests := []struct {
-		name           string
-		input          string
-		initialState   *GameState
-		expectedState  *GameState
-		expectedRetval bool
+		name            string
+		input           string
+		initialState    *GameState
+		expectedState   *GameState
+		expectedMessage string
+		expectedRetval  bool
 	}{
 		{
 			name:  "help command",
@@ -25,9 +26,9 @@ func TestHandleCommand(t *testing.T) {
 				Location:  LocRegister,
 				Inventory: make(map[Item]bool),
 				Clues:     make(map[string]string),
-				Message:   "Commands: look (l), go [place] (g), examine [item/area] (x), take [item] (t), use [item/code] (u), inventory (i), help (h), escape. \nUse 'examine' or 'look' for more details (handled by AI if available).",
 			},
-			expectedRetval: true,
+			expectedMessage: "Commands: look (l), go [place/direction/back] (g), n/s/e/w, back, examine [item/area] (x), take [item] (t), use [item/code] (u), inventory (i), accuse [suspect], hint, help (h), escape. \nUse 'examine' or 'look' for more details (handled by AI if available).",
+			expectedRetval:  true,
 		},
 		{
 			name:  "go to security station",
@@ -41,9 +42,9 @@ func TestHandleCommand(t *testing.T) {
 				Location:  LocSecurityStation,
 				Inventory: make(map[Item]bool),
 				Clues:     make(map[string]string),
-				Message:   "You hurry towards the back of the store, near the electronics section where the scream came from.",
 			},
-			expectedRetval: true,
+			expectedMessage: "You hurry towards the back of the store, near the electronics section where the scream came from.",
+			expectedRetval:  true,
 		},
 		{
 			name:  "take voucher",
@@ -58,10 +59,10 @@ func TestHandleCommand(t *testing.T) {
 				Inventory: map[Item]bool{
 					ItemVoucher: true,
 				},
-				Clues:   make(map[string]string),
-				Message: "You take the crumpled employee discount voucher.",
+				Clues: make(map[string]string),
 			},
-			expectedRetval: true,
+			expectedMessage: "You take the crumpled employee discount voucher.",
+			expectedRetval:  true,
 		},
 		{
 			name:  "examine fallback",
@@ -75,9 +76,9 @@ func TestHandleCommand(t *testing.T) {
 				Location:  LocSecurityStation,
 				Inventory: make(map[Item]bool),
 				Clues:     make(map[string]string),
-				Message:   "Dale's body is here. Monitors dark. Scanner nearby? Voucher clutched? Dale clutches a voucher. A scanner lies nearby.",
 			},
-			expectedRetval: false,
+			expectedMessage: "Dale's body is here. Monitors dark. Scanner nearby? Voucher clutched? Dale clutches a voucher. A scanner lies nearby.",
+			expectedRetval:  false,
 		},
 		{
 			name:  "correct locker code",
@@ -97,9 +98,9 @@ func TestHandleCommand(t *testing.T) {
 					"locker_opened": "true",
 				},
 				InputRequired: "",
-				Message:       "Click! The locker swings open.\nYou find a small notebook inside and take it.",
 			},
-			expectedRetval: true,
+			expectedMessage: "Click! The locker swings open.\nYou find a small notebook inside and take it.",
+			expectedRetval:  true,
 		},
 	}
 
@@ -108,8 +109,8 @@ func TestHandleCommand(t *testing.T) {
 			gs := tt.initialState
 			result := gs.HandleCommand(tt.input)
 
-			if result != tt.expectedRetval {
-				t.Errorf("HandleCommand() returned %v, want %v", result, tt.expectedRetval)
+			if result.Handled != tt.expectedRetval {
+				t.Errorf("HandleCommand() Handled = %v, want %v", result.Handled, tt.expectedRetval)
 			}
 
 			// Check location
@@ -134,8 +135,8 @@ func TestHandleCommand(t *testing.T) {
 			}
 
 			// Check message (strip whitespace to focus on content)
-			actualMsg := strings.TrimSpace(gs.Message)
-			expectedMsg := strings.TrimSpace(tt.expectedState.Message)
+			actualMsg := strings.TrimSpace(result.Text())
+			expectedMsg := strings.TrimSpace(tt.expectedMessage)
 			if actualMsg != expectedMsg {
 				t.Errorf("Message = %q, want %q", actualMsg, expectedMsg)
 			}
@@ -213,3 +214,61 @@ func TestIsCriticalUse(t *testing.T) {
 		})
 	}
 }
+
+func TestDirectionalMovement(t *testing.T) {
+	tests := []struct {
+		name             string
+		commands         []string
+		expectedLocation Location
+		expectedMessage  string
+	}{
+		{
+			name:             "compass abbreviation",
+			commands:         []string{"n"},
+			expectedLocation: LocSecurityStation,
+		},
+		{
+			name:             "go with direction",
+			commands:         []string{"go north", "go west"},
+			expectedLocation: LocManagersOffice,
+		},
+		{
+			name:             "back returns to the previous room",
+			commands:         []string{"go security", "go lockers", "back"},
+			expectedLocation: LocSecurityStation,
+		},
+		{
+			name:             "go to previous room",
+			commands:         []string{"n", "e", "w", "go to previous room"},
+			expectedLocation: LocLockerArea,
+		},
+		{
+			name:             "no exit in that direction",
+			commands:         []string{"s"},
+			expectedLocation: LocRegister,
+			expectedMessage:  "You can't go south from here.",
+		},
+		{
+			name:             "gated direction",
+			commands:         []string{"n", "n"},
+			expectedLocation: LocSecurityStation,
+			expectedMessage:  "You aren't sure exactly where the loading dock or the specific breaker panel is.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			var result Result
+			for _, cmd := range tt.commands {
+				result = gs.HandleCommand(cmd)
+			}
+			if gs.Location != tt.expectedLocation {
+				t.Errorf("Location = %v, want %v", gs.Location.ID(), tt.expectedLocation.ID())
+			}
+			if tt.expectedMessage != "" && result.Text() != tt.expectedMessage {
+				t.Errorf("Message = %q, want %q", result.Text(), tt.expectedMessage)
+			}
+		})
+	}
+}
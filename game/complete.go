@@ -0,0 +1,64 @@
+package game
+
+import "strings"
+
+// --- Tab Completion ---
+
+// Characters lists the people the player can talk to or ask about.
+var Characters = []string{"Brenda", "Gary", "Dale"}
+
+// completionVerbs are the verbs offered when completing a bare command
+var completionVerbs = []string{"go", "take", "use", "examine", "look", "talk", "ask", "search", "inventory", "accuse", "notebook", "hint", "help", "escape", "back"}
+
+// Completions returns every full command line that makes sense right now:
+// verbs, exits from the current location, items in reach and characters.
+// Frontends match these against what the player has typed so far.
+func (gs *GameState) Completions() []string {
+	completions := append([]string{}, completionVerbs...)
+	add := func(verb string, objects []string) {
+		for _, object := range objects {
+			completions = append(completions, verb+" "+object)
+		}
+	}
+
+	// Exits, by direction and by destination
+	exits := []string{}
+	for _, exit := range ExitsFrom(gs.Location) {
+		if gs.hasClue(exit.RequiresClue) {
+			exits = append(exits, exit.Direction, exit.To.ID())
+		}
+	}
+	if len(gs.Trail) > 0 {
+		exits = append(exits, "back")
+	}
+	add("go", exits)
+
+	// Items on the floor and in the inventory, in a stable order
+	visible, carried := []string{}, []string{}
+	for _, placement := range Placements {
+		if gs.Inventory[placement.Item] {
+			carried = append(carried, string(placement.Item))
+		} else if placement.Location == gs.Location && gs.hasClue(placement.RequiresClue) {
+			visible = append(visible, string(placement.Item))
+		}
+	}
+	add("take", visible)
+	add("use", carried)
+	add("examine", append(append(append([]string{}, carried...), visible...), lowerAll(Characters)...))
+
+	// Characters
+	add("talk", lowerAll(Characters))
+	add("ask", lowerAll(Characters))
+	add("accuse", lowerAll(Suspects))
+
+	return completions
+}
+
+// lowerAll returns a lower-cased copy of names
+func lowerAll(names []string) []string {
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(name)
+	}
+	return lowered
+}
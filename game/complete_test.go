@@ -0,0 +1,29 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompletions(t *testing.T) {
+	gs := &GameState{
+		Location:  LocSecurityStation,
+		Inventory: map[Item]bool{ItemVoucher: true},
+		Clues:     map[string]string{},
+	}
+	completions := gs.Completions()
+
+	expected := []string{"help", "go east", "go office", "take Dale's handheld scanner", "use crumpled employee discount voucher", "examine crumpled employee discount voucher", "talk brenda"}
+	for _, want := range expected {
+		if !slices.Contains(completions, want) {
+			t.Errorf("Completions() missing %q", want)
+		}
+	}
+
+	unexpected := []string{"go dock", "take crumpled employee discount voucher", "go back"}
+	for _, unwanted := range unexpected {
+		if slices.Contains(completions, unwanted) {
+			t.Errorf("Completions() should not contain %q", unwanted)
+		}
+	}
+}
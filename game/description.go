@@ -2,12 +2,18 @@ package game
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
 // GetLocationName returns a short name for the current location.
 func (gs *GameState) GetLocationName() string {
-	switch gs.Location {
+	return gs.Location.Name()
+}
+
+// Name returns a short display name for the location.
+func (l Location) Name() string {
+	switch l {
 	case LocRegister:
 		return "Near Register 4 (Front)"
 	case LocSecurityStation:
@@ -25,6 +31,26 @@ func (gs *GameState) GetLocationName() string {
 	}
 }
 
+// ID returns a stable identifier for the location, used in exported maps.
+func (l Location) ID() string {
+	switch l {
+	case LocRegister:
+		return "register"
+	case LocSecurityStation:
+		return "security"
+	case LocLockerArea:
+		return "lockers"
+	case LocManagersOffice:
+		return "office"
+	case LocLoadingDock:
+		return "dock"
+	case LocEscaped:
+		return "escaped"
+	default:
+		return "unknown"
+	}
+}
+
 // GetLocationDescription provides the base description for the current location.
 func (gs *GameState) GetLocationDescription() string {
 	// These are base descriptions; LLM can elaborate when examining the area.
@@ -58,30 +84,26 @@ func (gs *GameState) GetLocationDescription() string {
 	}
 }
 
+// GetExitsDescription lists the exits the player knows how to take from here.
+func (gs *GameState) GetExitsDescription() string {
+	exits := []string{}
+	for _, exit := range ExitsFrom(gs.Location) {
+		if gs.hasClue(exit.RequiresClue) {
+			exits = append(exits, fmt.Sprintf("%s to %s", exit.Direction, exit.To.Name()))
+		}
+	}
+	if len(exits) == 0 {
+		return ""
+	}
+	return "Exits: " + strings.Join(exits, ", ") + "."
+}
+
 // GetVisibleItems lists items available to 'take' in the current location.
 func (gs *GameState) GetVisibleItems() string {
 	items := []string{}
-	switch gs.Location {
-	case LocSecurityStation:
-		if !gs.Inventory[ItemVoucher] {
-			items = append(items, string(ItemVoucher))
-		}
-		if !gs.Inventory[ItemScanner] {
-			items = append(items, string(ItemScanner))
-		}
-	case LocLockerArea:
-		if _, opened := gs.Clues["locker_opened"]; opened && !gs.Inventory[ItemNotebook] {
-			items = append(items, string(ItemNotebook))
-		}
-	case LocManagersOffice:
-		if !gs.Inventory[ItemCard] {
-			items = append(items, string(ItemCard))
-		}
-		if !gs.Inventory[ItemInventory] {
-			items = append(items, string(ItemInventory))
-		}
-		if _, opened := gs.Clues["safe_opened"]; opened && !gs.Inventory[ItemOverrideKey] {
-			items = append(items, string(ItemOverrideKey))
+	for _, placement := range Placements {
+		if placement.Location == gs.Location && gs.hasClue(placement.RequiresClue) && !gs.Inventory[placement.Item] {
+			items = append(items, string(placement.Item))
 		}
 	}
 	if len(items) > 0 {
@@ -99,16 +121,9 @@ func (gs *GameState) GetInventoryDescription() string {
 	for itm := range gs.Inventory {
 		items = append(items, string(itm))
 	}
-	// Sort inventory for consistent display? (Optional)
-	// sort.Strings(items)
+	// Sort inventory for consistent display
+	slices.SortFunc(items, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
 	return "Inventory: " + strings.Join(items, ", ") + "."
 }
-
-// GetInputPrompt returns the input prompt string.
-func (gs *GameState) GetInputPrompt() string {
-	prompt := "> "
-	if gs.InputRequired != "" {
-		prompt = fmt.Sprintf("Enter %s: ", strings.ReplaceAll(gs.InputRequired, "_", " "))
-	}
-	return prompt + gs.CurrentInput // Display current typed input
-}
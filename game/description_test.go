@@ -240,3 +240,41 @@ func TestGetVisibleItems(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExitsDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    *GameState
+		expected string
+	}{
+		{
+			name:     "register",
+			state:    &GameState{Location: LocRegister},
+			expected: "Exits: north to Security Station (Electronics).",
+		},
+		{
+			name:     "office without the map",
+			state:    &GameState{Location: LocManagersOffice, Clues: map[string]string{}},
+			expected: "Exits: east to Security Station (Electronics).",
+		},
+		{
+			name:     "office with the map",
+			state:    &GameState{Location: LocManagersOffice, Clues: map[string]string{"map_details": "true"}},
+			expected: "Exits: east to Security Station (Electronics), northeast to Loading Dock (Back).",
+		},
+		{
+			name:     "escaped",
+			state:    &GameState{Location: LocEscaped},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.state.GetExitsDescription()
+			if result != tt.expected {
+				t.Errorf("GetExitsDescription() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
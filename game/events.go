@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Events ---
+
+// Event is something that happened in the game world. Subscribers receive
+// events as HandleCommand makes changes, so they can react without
+// inspecting the state themselves.
+type Event interface {
+	fmt.Stringer
+	isEvent()
+}
+
+// Lock identifies a puzzle lock that can be opened with a code
+type Lock string
+
+const (
+	LockLocker  Lock = "locker"
+	LockSafe    Lock = "safe"
+	LockBreaker Lock = "breaker_panel"
+)
+
+// ItemTaken is emitted when an item goes into the inventory.
+type ItemTaken struct {
+	Item Item
+}
+
+// LocationEntered is emitted when the player moves to another location.
+type LocationEntered struct {
+	From Location
+	To   Location
+}
+
+// LockOpened is emitted when the right code opens a lock.
+type LockOpened struct {
+	Lock Lock
+}
+
+// ClueDiscovered is emitted the first time a clue is found.
+type ClueDiscovered struct {
+	Clue ClueDefinition
+}
+
+// GameEnded is emitted when the player escapes.
+type GameEnded struct {
+	Ending string
+}
+
+func (ItemTaken) isEvent()       {}
+func (LocationEntered) isEvent() {}
+func (LockOpened) isEvent()      {}
+func (ClueDiscovered) isEvent()  {}
+func (GameEnded) isEvent()       {}
+
+func (e ItemTaken) String() string {
+	return fmt.Sprintf("Took the %s", e.Item)
+}
+
+func (e LocationEntered) String() string {
+	return fmt.Sprintf("Went from %s to %s", e.From.Name(), e.To.Name())
+}
+
+func (e LockOpened) String() string {
+	return fmt.Sprintf("Opened the %s", strings.ReplaceAll(string(e.Lock), "_", " "))
+}
+
+func (e ClueDiscovered) String() string {
+	return fmt.Sprintf("Found a clue: %s", e.Clue.Title)
+}
+
+func (e GameEnded) String() string {
+	return fmt.Sprintf("Escaped the store (%s ending)", e.Ending)
+}
+
+// subscriber is a registered event handler
+type subscriber struct {
+	id     int
+	handle func(Event)
+}
+
+// Subscribe registers a handler for every event the game emits from now on.
+// Handlers run synchronously, in subscription order. Returns a function that
+// removes the handler.
+func (gs *GameState) Subscribe(handle func(Event)) (unsubscribe func()) {
+	gs.nextSubscriberID++
+	id := gs.nextSubscriberID
+	gs.subscribers = append(gs.subscribers, subscriber{id: id, handle: handle})
+	return func() {
+		for i, sub := range gs.subscribers {
+			if sub.id == id {
+				gs.subscribers = append(gs.subscribers[:i:i], gs.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emit adds an event to the current result and delivers it to every subscriber
+func (gs *GameState) emit(e Event) {
+	if gs.out != nil {
+		gs.out.Events = append(gs.out.Events, e)
+	}
+	for _, sub := range gs.subscribers {
+		sub.handle(e)
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEventsEmitted(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(gs *GameState)
+		commands []string
+		expected []Event
+	}{
+		{
+			name:     "entering the security station",
+			commands: []string{"go security"},
+			expected: []Event{
+				LocationEntered{From: LocRegister, To: LocSecurityStation},
+				ClueDiscovered{Clue: mustFindClue(t, "puncture_wound")},
+			},
+		},
+		{
+			name:     "taking an item",
+			setup:    func(gs *GameState) { gs.Location = LocSecurityStation },
+			commands: []string{"take " + string(ItemScanner)},
+			expected: []Event{
+				ItemTaken{Item: ItemScanner},
+				ClueDiscovered{Clue: mustFindClue(t, "frozen_scan")},
+			},
+		},
+		{
+			name:     "opening the safe",
+			setup:    func(gs *GameState) { gs.Location = LocManagersOffice },
+			commands: []string{"use 4711", "4711"},
+			expected: []Event{
+				LockOpened{Lock: LockSafe},
+				ItemTaken{Item: ItemOverrideKey},
+				ClueDiscovered{Clue: mustFindClue(t, "override_key")},
+			},
+		},
+		{
+			name: "escaping",
+			setup: func(gs *GameState) {
+				gs.Location = LocLoadingDock
+				gs.Clues["door_unlocked"] = "true"
+			},
+			commands: []string{"escape"},
+			expected: []Event{GameEnded{Ending: EndingFled}},
+		},
+		{
+			name:     "wrong code emits nothing",
+			setup:    func(gs *GameState) { gs.Location = LocManagersOffice },
+			commands: []string{"use 4711", "1234"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			if tt.setup != nil {
+				tt.setup(gs)
+			}
+			var got []Event
+			gs.Subscribe(func(e Event) { got = append(got, e) })
+			for _, command := range tt.commands {
+				gs.HandleCommand(command)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("events = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	gs := NewGameState()
+	var first, second []Event
+	unsubscribe := gs.Subscribe(func(e Event) { first = append(first, e) })
+	gs.Subscribe(func(e Event) { second = append(second, e) })
+
+	gs.HandleCommand("go security")
+	unsubscribe()
+	gs.HandleCommand("go back")
+
+	if got := len(first); got != 2 {
+		t.Errorf("unsubscribed handler saw %d events, want 2", got)
+	}
+	if got := len(second); got != 3 {
+		t.Errorf("subscribed handler saw %d events, want 3", got)
+	}
+}
+
+// mustFindClue looks up a clue definition, failing the test if it is missing
+func mustFindClue(t *testing.T, id string) ClueDefinition {
+	t.Helper()
+	def, ok := FindClue(id)
+	if !ok {
+		t.Fatalf("clue %q not in catalog", id)
+	}
+	return def
+}
@@ -0,0 +1,251 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// --- Evidence ---
+
+// Suspects lists the people the player can connect clues to and accuse.
+var Suspects = []string{"Brenda", "Gary"}
+
+// Killer is the suspect who murdered Dale.
+const Killer = "Gary"
+
+// ClueDefinition is an authored piece of evidence the player can uncover.
+type ClueDefinition struct {
+	ID      string
+	Title   string
+	Source  string // Item or person the clue came from
+	Details string
+	Flag    string // Optional game flag set in Clues when discovered
+}
+
+// ClueCatalog lists every piece of evidence in story order.
+var ClueCatalog = []ClueDefinition{
+	{
+		ID:      "puncture_wound",
+		Title:   "Puncture wound",
+		Source:  "Dale's body",
+		Details: "Dale has a small puncture wound in his neck. This was no accident.",
+	},
+	{
+		ID:      "last_scan",
+		Title:   "Aisle 13, last scan",
+		Source:  string(ItemVoucher),
+		Details: "The back of the voucher reads AISLE 13 // LAST SCAN in Dale's handwriting.",
+	},
+	{
+		ID:      "frozen_scan",
+		Title:   "Frozen product ID",
+		Source:  string(ItemScanner),
+		Details: "Dale's scanner is frozen on product ID 8675309.",
+	},
+	{
+		ID:      "skimming_notes",
+		Title:   "Dale's suspicions",
+		Source:  string(ItemNotebook),
+		Details: "Dale wrote that someone was skimming stock and mentions both Brenda and Gary.",
+	},
+	{
+		ID:      "silent_alarm",
+		Title:   "OVERSTOCK silent alarm",
+		Source:  string(ItemNotebook),
+		Details: "The notebook mentions a silent alarm keyed to the word OVERSTOCK.",
+	},
+	{
+		ID:      "breaker_map",
+		Title:   "Map to the breaker panel",
+		Source:  string(ItemNotebook),
+		Details: "A crude map shows the breaker panel at the loading dock. It needs the manager's key.",
+		Flag:    "map_details",
+	},
+	{
+		ID:      "emergency_procedure",
+		Title:   "Emergency override procedure",
+		Source:  string(ItemCard),
+		Details: "The override key is kept in the manager's safe; the code comes from the inventory sheet.",
+	},
+	{
+		ID:      "safe_code",
+		Title:   "OVERSTOCK line on the inventory",
+		Source:  string(ItemInventory),
+		Details: "The inventory printout lists OVERSTOCK next to the number 4711.",
+	},
+	{
+		ID:      "override_key",
+		Title:   "Manual override key",
+		Source:  string(ItemOverrideKey),
+		Details: "The key from the manager's safe, labeled 'Manual Override'. Only the manager had the combination.",
+	},
+	{
+		ID:      "confession",
+		Title:   "Brenda's accusation",
+		Source:  "Brenda",
+		Details: "'Dale knew you were skimming, Gary!' Brenda shouted after Gary lunged at you.",
+	},
+}
+
+// itemClues maps items to the evidence found by picking them up.
+var itemClues = map[Item][]string{
+	ItemVoucher:     {"last_scan"},
+	ItemScanner:     {"frozen_scan"},
+	ItemNotebook:    {"skimming_notes", "silent_alarm", "breaker_map"},
+	ItemCard:        {"emergency_procedure"},
+	ItemInventory:   {"safe_code"},
+	ItemOverrideKey: {"override_key"},
+}
+
+// locationClues maps locations to the evidence found by entering them.
+var locationClues = map[Location][]string{
+	LocSecurityStation: {"puncture_wound"},
+}
+
+// Evidence is a clue the player has discovered, along with their own notes.
+type Evidence struct {
+	ClueDefinition
+	FoundAt time.Time
+	Pinned  bool     // Kept at the top of the clue board
+	Linked  []string // Suspects the player has connected this clue to
+}
+
+// FindClue returns the authored definition for a clue ID.
+func FindClue(id string) (ClueDefinition, bool) {
+	for _, def := range ClueCatalog {
+		if def.ID == id {
+			return def, true
+		}
+	}
+	return ClueDefinition{}, false
+}
+
+// HasEvidence reports whether the player has discovered a clue.
+func (gs *GameState) HasEvidence(id string) bool {
+	return gs.evidenceIndex(id) >= 0
+}
+
+// discover records a clue the first time it is found. Returns true if it was new.
+func (gs *GameState) discover(id string) bool {
+	def, ok := FindClue(id)
+	if !ok || gs.HasEvidence(id) {
+		return false
+	}
+	gs.Evidence = append(gs.Evidence, Evidence{ClueDefinition: def, FoundAt: Now()})
+	if def.Flag != "" {
+		if gs.Clues == nil {
+			gs.Clues = make(map[string]string)
+		}
+		gs.Clues[def.Flag] = "true"
+	}
+	gs.emit(ClueDiscovered{Clue: def})
+	return true
+}
+
+// acquire puts an item in the inventory and notes the evidence it carries.
+func (gs *GameState) acquire(item Item) {
+	gs.Inventory[item] = true
+	gs.emit(ItemTaken{Item: item})
+	for _, id := range itemClues[item] {
+		gs.discover(id)
+	}
+}
+
+// TogglePin pins or unpins a discovered clue on the board.
+func (gs *GameState) TogglePin(id string) {
+	if i := gs.evidenceIndex(id); i >= 0 {
+		gs.Evidence[i].Pinned = !gs.Evidence[i].Pinned
+	}
+}
+
+// ToggleLink connects a discovered clue to a suspect, or disconnects it.
+func (gs *GameState) ToggleLink(id, suspect string) {
+	i := gs.evidenceIndex(id)
+	if i < 0 || !slices.Contains(Suspects, suspect) {
+		return
+	}
+	linked := slices.Clone(gs.Evidence[i].Linked)
+	if at := slices.Index(linked, suspect); at >= 0 {
+		linked = slices.Delete(linked, at, at+1)
+	} else {
+		linked = append(linked, suspect)
+	}
+	gs.Evidence[i].Linked = linked
+}
+
+// EvidenceAgainst returns the clues the player has linked to a suspect.
+func (gs *GameState) EvidenceAgainst(suspect string) []Evidence {
+	against := []Evidence{}
+	for _, ev := range gs.Evidence {
+		if slices.Contains(ev.Linked, suspect) {
+			against = append(against, ev)
+		}
+	}
+	return against
+}
+
+// evidenceIndex finds a discovered clue, or -1
+func (gs *GameState) evidenceIndex(id string) int {
+	for i, ev := range gs.Evidence {
+		if ev.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleAccuse lets the player name the killer, backed by the clues they
+// connected to that suspect on the clue board.
+func (gs *GameState) handleAccuse(name string) {
+	suspect := ""
+	for _, s := range Suspects {
+		if strings.EqualFold(s, strings.TrimSpace(name)) {
+			suspect = s
+		}
+	}
+	if suspect == "" {
+		gs.say(MessageError, fmt.Sprintf("Who are you accusing? The suspects are %s.", strings.Join(Suspects, " and ")))
+		return
+	}
+
+	against := gs.EvidenceAgainst(suspect)
+	if len(against) == 0 {
+		gs.say(MessageError, fmt.Sprintf("You have nothing connecting %s to Dale's death yet. Link some clues to them in your notebook first.", suspect))
+		return
+	}
+
+	gs.Accused = suspect
+	if suspect == Killer {
+		gs.say(MessageStory, fmt.Sprintf("You lay out %d piece(s) of evidence. Gary's jaw tightens. 'You can't prove anything,' he mutters, edging toward the back of the store.", len(against)))
+	} else {
+		gs.say(MessageStory, fmt.Sprintf("You lay out %d piece(s) of evidence. Brenda stares at you, stunned. 'Me? Dale was my friend.' Gary says nothing, but he almost smiles.", len(against)))
+	}
+}
+
+// --- Endings ---
+
+// Ways a playthrough can end, depending on who the player accused before escaping
+const (
+	EndingJustice      = "justice"       // Escaped after accusing the killer
+	EndingWrongSuspect = "wrong_suspect" // Escaped after accusing an innocent
+	EndingFled         = "fled"          // Escaped without accusing anyone
+)
+
+// Endings lists every ending in the game.
+var Endings = []string{EndingJustice, EndingWrongSuspect, EndingFled}
+
+// Ending returns how the game ended, or "" while it is still being played.
+func (gs *GameState) Ending() string {
+	switch {
+	case !gs.GameOver:
+		return ""
+	case gs.Accused == "":
+		return EndingFled
+	case gs.Accused == Killer:
+		return EndingJustice
+	default:
+		return EndingWrongSuspect
+	}
+}
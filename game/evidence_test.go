@@ -0,0 +1,91 @@
+package game
+
+import "testing"
+
+func TestEvidenceDiscovery(t *testing.T) {
+	gs := NewGameState()
+	gs.HandleCommand("go security")
+	gs.HandleCommand("take crumpled employee discount voucher")
+	gs.HandleCommand("take crumpled employee discount voucher") // Already have it
+
+	for _, id := range []string{"puncture_wound", "last_scan"} {
+		if !gs.HasEvidence(id) {
+			t.Errorf("HasEvidence(%q) = false, want true", id)
+		}
+	}
+	if len(gs.Evidence) != 2 {
+		t.Errorf("len(Evidence) = %d, want 2", len(gs.Evidence))
+	}
+}
+
+func TestNotebookRevealsMap(t *testing.T) {
+	gs := &GameState{
+		Location:      LocLockerArea,
+		Inventory:     make(map[Item]bool),
+		Clues:         make(map[string]string),
+		InputRequired: "locker_code",
+	}
+	gs.HandleCommand("8675309")
+
+	if !gs.HasEvidence("breaker_map") {
+		t.Errorf("HasEvidence(breaker_map) = false after opening the locker")
+	}
+	if gs.Clues["map_details"] != "true" {
+		t.Errorf("Clues[map_details] = %q, want %q", gs.Clues["map_details"], "true")
+	}
+}
+
+func TestAccuse(t *testing.T) {
+	tests := []struct {
+		name            string
+		link            bool
+		input           string
+		expectedAccused string
+	}{
+		{
+			name:            "without linked evidence",
+			input:           "accuse gary",
+			expectedAccused: "",
+		},
+		{
+			name:            "with linked evidence",
+			link:            true,
+			input:           "accuse gary",
+			expectedAccused: "Gary",
+		},
+		{
+			name:            "unknown suspect",
+			link:            true,
+			input:           "accuse dale",
+			expectedAccused: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			gs.discover("skimming_notes")
+			if tt.link {
+				gs.ToggleLink("skimming_notes", "Gary")
+			}
+			result := gs.HandleCommand(tt.input)
+			if gs.Accused != tt.expectedAccused {
+				t.Errorf("Accused = %q, want %q (message %q)", gs.Accused, tt.expectedAccused, result.Text())
+			}
+		})
+	}
+}
+
+func TestToggleLink(t *testing.T) {
+	gs := NewGameState()
+	gs.discover("skimming_notes")
+
+	gs.ToggleLink("skimming_notes", "Brenda")
+	if got := len(gs.EvidenceAgainst("Brenda")); got != 1 {
+		t.Fatalf("EvidenceAgainst(Brenda) has %d clues, want 1", got)
+	}
+	gs.ToggleLink("skimming_notes", "Brenda")
+	if got := len(gs.EvidenceAgainst("Brenda")); got != 0 {
+		t.Errorf("EvidenceAgainst(Brenda) has %d clues after unlinking, want 0", got)
+	}
+}
@@ -0,0 +1,141 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// --- World Map Export ---
+
+// WriteDOT writes the world map as a Graphviz DOT digraph. Exits are solid
+// edges, gated exits are dashed and labeled with their clue, one-way exits are
+// red and item placements hang off their location as boxes.
+func WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString("digraph blackoutbargain {\n")
+	sb.WriteString("\trankdir=LR;\n")
+	sb.WriteString("\tnode [shape=ellipse];\n\n")
+
+	for _, loc := range Locations {
+		sb.WriteString(fmt.Sprintf("\t%s [label=%q];\n", loc.ID(), loc.Name()))
+	}
+	sb.WriteString("\n")
+
+	oneWay := oneWaySet()
+	for i, exit := range Exits {
+		attrs := []string{}
+		if exit.RequiresClue != "" {
+			attrs = append(attrs, fmt.Sprintf("label=%q", exit.RequiresClue), "style=dashed")
+		}
+		if oneWay[i] {
+			attrs = append(attrs, "color=red")
+		}
+		sb.WriteString(fmt.Sprintf("\t%s -> %s%s;\n", exit.From.ID(), exit.To.ID(), dotAttrs(attrs)))
+	}
+	sb.WriteString(fmt.Sprintf("\t%s -> %s [label=%q, style=bold];\n\n", LocLoadingDock.ID(), LocEscaped.ID(), "escape: door_unlocked"))
+
+	for _, placement := range Placements {
+		sb.WriteString(fmt.Sprintf("\t%s [label=%q, shape=box];\n", itemID(placement.Item), string(placement.Item)))
+		attrs := []string{"style=dotted", "arrowhead=none"}
+		if placement.RequiresClue != "" {
+			attrs = append(attrs, fmt.Sprintf("label=%q", placement.RequiresClue))
+		}
+		sb.WriteString(fmt.Sprintf("\t%s -> %s%s;\n", placement.Location.ID(), itemID(placement.Item), dotAttrs(attrs)))
+	}
+
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMermaid writes the world map as a Mermaid flowchart using the same
+// conventions as WriteDOT.
+func WriteMermaid(w io.Writer) error {
+	var sb strings.Builder
+	link := 0 // Mermaid styles links by their index
+	redLinks := []string{}
+
+	sb.WriteString("flowchart LR\n")
+
+	for _, loc := range Locations {
+		sb.WriteString(fmt.Sprintf("\t%s(\"%s\")\n", loc.ID(), mermaidText(loc.Name())))
+	}
+
+	oneWay := oneWaySet()
+	for i, exit := range Exits {
+		if exit.RequiresClue != "" {
+			sb.WriteString(fmt.Sprintf("\t%s -.->|\"%s\"| %s\n", exit.From.ID(), mermaidText(exit.RequiresClue), exit.To.ID()))
+		} else {
+			sb.WriteString(fmt.Sprintf("\t%s --> %s\n", exit.From.ID(), exit.To.ID()))
+		}
+		if oneWay[i] {
+			redLinks = append(redLinks, fmt.Sprint(link))
+		}
+		link++
+	}
+	sb.WriteString(fmt.Sprintf("\t%s ==>|\"escape: door_unlocked\"| %s\n", LocLoadingDock.ID(), LocEscaped.ID()))
+	link++
+
+	for _, placement := range Placements {
+		sb.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", itemID(placement.Item), mermaidText(string(placement.Item))))
+		if placement.RequiresClue != "" {
+			sb.WriteString(fmt.Sprintf("\t%s -.-|\"%s\"| %s\n", placement.Location.ID(), mermaidText(placement.RequiresClue), itemID(placement.Item)))
+		} else {
+			sb.WriteString(fmt.Sprintf("\t%s -.- %s\n", placement.Location.ID(), itemID(placement.Item)))
+		}
+		link++
+	}
+
+	if len(redLinks) > 0 {
+		sb.WriteString(fmt.Sprintf("\tlinkStyle %s stroke:red\n", strings.Join(redLinks, ",")))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// oneWaySet marks the indexes into Exits that have no return path.
+func oneWaySet() map[int]bool {
+	set := make(map[int]bool)
+	for _, exit := range OneWayExits() {
+		for i, candidate := range Exits {
+			if candidate.From == exit.From && candidate.To == exit.To {
+				set[i] = true
+			}
+		}
+	}
+	return set
+}
+
+// dotAttrs formats an attribute list for a DOT edge.
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// itemID turns an item name into a node identifier, e.g. "item_small_notebook".
+func itemID(item Item) string {
+	var sb strings.Builder
+	sb.WriteString("item_")
+	underscore := false
+	for _, r := range strings.ToLower(string(item)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			underscore = false
+		} else if !underscore {
+			sb.WriteRune('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+// mermaidText escapes double quotes, which Mermaid cannot nest in labels.
+func mermaidText(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
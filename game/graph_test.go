@@ -0,0 +1,62 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOneWayExits(t *testing.T) {
+	if oneWay := OneWayExits(); len(oneWay) != 0 {
+		for _, exit := range oneWay {
+			t.Errorf("exit %s -> %s has no way back", exit.From.ID(), exit.To.ID())
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteDOT(&sb); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	out := sb.String()
+
+	expected := []string{
+		"digraph blackoutbargain {",
+		`register [label="Near Register 4 (Front)"];`,
+		"register -> security;",
+		`security -> dock [label="map_details", style=dashed];`,
+		`dock -> escaped [label="escape: door_unlocked", style=bold];`,
+		`item_small_notebook [label="small notebook", shape=box];`,
+		`lockers -> item_small_notebook [style=dotted, arrowhead=none, label="locker_opened"];`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteDOT() output missing %q", want)
+		}
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var sb strings.Builder
+	if err := WriteMermaid(&sb); err != nil {
+		t.Fatalf("WriteMermaid() error = %v", err)
+	}
+	out := sb.String()
+
+	expected := []string{
+		"flowchart LR",
+		`office("Manager's Office")`,
+		"security --> lockers",
+		`office -.->|"map_details"| dock`,
+		`dock ==>|"escape: door_unlocked"| escaped`,
+		`office -.-|"safe_opened"| item_manual_override_key`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteMermaid() output missing %q", want)
+		}
+	}
+	if strings.Contains(out, "linkStyle") {
+		t.Errorf("WriteMermaid() highlighted one-way links in a fully connected map")
+	}
+}
@@ -0,0 +1,118 @@
+package game
+
+import "fmt"
+
+// --- Hints ---
+
+// hintTierNames labels each level of hint, from vague to explicit
+var hintTierNames = []string{"nudge", "direction", "answer"}
+
+// Puzzle is a step on the path to escaping, with hints of rising specificity.
+type Puzzle struct {
+	ID    string
+	Done  func(gs *GameState) bool
+	Hints [3]string // Nudge, direction, answer
+}
+
+// Puzzles lists the steps of the escape in the order the player meets them.
+var Puzzles = []Puzzle{
+	{
+		ID:   "find_dale",
+		Done: func(gs *GameState) bool { return gs.HasVisited(LocSecurityStation) },
+		Hints: [3]string{
+			"The scream came from somewhere deeper in the store.",
+			"Head to the security station in the electronics section.",
+			"Type 'go security' (or just 'n') from the registers.",
+		},
+	},
+	{
+		ID:   "search_dale",
+		Done: func(gs *GameState) bool { return gs.Inventory[ItemVoucher] && gs.Inventory[ItemScanner] },
+		Hints: [3]string{
+			"Dale may have left something behind for whoever found him.",
+			"Dale is clutching a voucher, and his scanner lies nearby.",
+			"At the security station, 'take crumpled employee discount voucher' and 'take Dale's handheld scanner'.",
+		},
+	},
+	{
+		ID:   "open_locker",
+		Done: func(gs *GameState) bool { return gs.hasClue("locker_opened") },
+		Hints: [3]string{
+			"Dale's scanner froze on a number. Numbers open things.",
+			"Try the scanner's product ID on Dale's locker, east of the security station.",
+			"Go to the lockers, type 'use 8675309' and set the dials to 8675309.",
+		},
+	},
+	{
+		ID:   "search_office",
+		Done: func(gs *GameState) bool { return gs.Inventory[ItemCard] && gs.Inventory[ItemInventory] },
+		Hints: [3]string{
+			"The notebook says the breaker panel needs the manager's key. Where would a manager keep things?",
+			"The manager's office, west of the security station, has a card on the corkboard and papers on the desk.",
+			"In the office, 'take laminated emergency procedure card' and 'take daily inventory printout'.",
+		},
+	},
+	{
+		ID:   "open_safe",
+		Done: func(gs *GameState) bool { return gs.hasClue("safe_opened") },
+		Hints: [3]string{
+			"The emergency card says the safe code comes from the inventory.",
+			"Look for the OVERSTOCK line on the inventory printout and try its number on the safe.",
+			"In the office, type 'use 4711' and set the dials to 4711.",
+		},
+	},
+	{
+		ID:   "restore_power",
+		Done: func(gs *GameState) bool { return gs.hasClue("door_unlocked") },
+		Hints: [3]string{
+			"Dale's map leads to the breaker panel. You have the key it needs.",
+			"At the loading dock, insert the override key and enter the word from Dale's notebook on the keypad.",
+			"Go to the loading dock, type 'use key' and enter OVERSTOCK (683778625 on the keypad).",
+		},
+	},
+	{
+		ID:   "escape",
+		Done: func(gs *GameState) bool { return gs.GameOver },
+		Hints: [3]string{
+			"The doors are unlocked. Nothing is keeping you here now.",
+			"The loading dock door leads outside.",
+			"At the loading dock, type 'escape'.",
+		},
+	},
+}
+
+// CurrentPuzzle returns the first puzzle the player hasn't solved yet.
+func (gs *GameState) CurrentPuzzle() (Puzzle, bool) {
+	for _, puzzle := range Puzzles {
+		if !puzzle.Done(gs) {
+			return puzzle, true
+		}
+	}
+	return Puzzle{}, false
+}
+
+// HintsUsed returns how many hints the player has asked for in total.
+func (gs *GameState) HintsUsed() int {
+	total := 0
+	for _, count := range gs.Hints {
+		total += count
+	}
+	return total
+}
+
+// handleHint gives the next hint for the current puzzle. Each request for
+// the same puzzle gets more specific until the answer is given.
+func (gs *GameState) handleHint() {
+	puzzle, ok := gs.CurrentPuzzle()
+	if !ok {
+		gs.say(MessageInfo, "You don't need any more help. You're free.")
+		return
+	}
+	if gs.Hints == nil {
+		gs.Hints = make(map[string]int)
+	}
+
+	tier := min(gs.Hints[puzzle.ID], len(puzzle.Hints)-1)
+	gs.Hints[puzzle.ID]++
+	gs.say(MessageInfo, fmt.Sprintf("Hint (%s, %d/%d): %s", hintTierNames[tier], tier+1, len(puzzle.Hints), puzzle.Hints[tier]))
+}
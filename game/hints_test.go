@@ -0,0 +1,47 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHintEscalates(t *testing.T) {
+	gs := NewGameState()
+
+	expected := []string{
+		"Hint (nudge, 1/3):",
+		"Hint (direction, 2/3):",
+		"Hint (answer, 3/3):",
+		"Hint (answer, 3/3):", // Stays on the answer
+	}
+	for i, want := range expected {
+		hint := gs.HandleCommand("hint").Text()
+		if !strings.HasPrefix(hint, want) {
+			t.Errorf("hint %d = %q, want prefix %q", i+1, hint, want)
+		}
+	}
+	if gs.HintsUsed() != len(expected) {
+		t.Errorf("HintsUsed() = %d, want %d", gs.HintsUsed(), len(expected))
+	}
+}
+
+func TestHintTracksPuzzles(t *testing.T) {
+	gs := NewGameState()
+	gs.HandleCommand("hint")
+	gs.HandleCommand("hint")
+	gs.HandleCommand("go security")
+
+	puzzle, _ := gs.CurrentPuzzle()
+	if puzzle.ID != "search_dale" {
+		t.Fatalf("CurrentPuzzle() = %q, want %q", puzzle.ID, "search_dale")
+	}
+
+	// A new puzzle starts again from a nudge
+	hint := gs.HandleCommand("hint").Text()
+	if !strings.HasPrefix(hint, "Hint (nudge, 1/3):") {
+		t.Errorf("first hint for new puzzle = %q, want a nudge", hint)
+	}
+	if gs.Hints["find_dale"] != 2 || gs.Hints["search_dale"] != 1 {
+		t.Errorf("Hints = %v, want find_dale:2 search_dale:1", gs.Hints)
+	}
+}
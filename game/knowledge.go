@@ -0,0 +1,65 @@
+package game
+
+// --- Player Knowledge ---
+
+// Secret is a solution the player has to work out, such as a lock code.
+// Frontends and the narrator must not reveal it before the player has
+// found one of the clues that give it away.
+type Secret struct {
+	ID         string
+	Values     []string // Every way the secret can be written
+	RevealedBy []string // Clue IDs that give the secret away
+}
+
+// Secrets lists the puzzle solutions in story order.
+var Secrets = []Secret{
+	{ID: "locker_code", Values: []string{"8675309"}, RevealedBy: []string{"frozen_scan"}},
+	{ID: "safe_code", Values: []string{"4711"}, RevealedBy: []string{"safe_code"}},
+	{ID: "breaker_code", Values: []string{"OVERSTOCK", "683778625"}, RevealedBy: []string{"silent_alarm", "safe_code"}},
+}
+
+// locationNotes tells the narrator what it needs to know about a place to
+// describe it, without giving anything away
+var locationNotes = map[Location]string{
+	LocRegister:        "Brenda and Gary wait by the registers, shaken and jumpy. The security station is to the north.",
+	LocSecurityStation: "Dale, the security guard, lies dead against the monitors. The player doesn't know yet how he died unless it is listed below.",
+	LocLockerArea:      "Dale's locker is closed with a seven-digit combination lock.",
+	LocManagersOffice:  "The manager's office. A small wall safe has a four-digit combination lock. A corkboard and a cluttered desk hold paperwork.",
+	LocLoadingDock:     "The breaker panel needs a manual override key and a keypad code before the magnetic door locks will release.",
+}
+
+// IsRevealed reports whether the player has found a clue that gives the secret away.
+func (gs *GameState) IsRevealed(secret Secret) bool {
+	for _, id := range secret.RevealedBy {
+		if gs.HasEvidence(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// HiddenSecrets returns every way of writing a secret the player hasn't learned yet.
+func (gs *GameState) HiddenSecrets() []string {
+	hidden := []string{}
+	for _, secret := range Secrets {
+		if !gs.IsRevealed(secret) {
+			hidden = append(hidden, secret.Values...)
+		}
+	}
+	return hidden
+}
+
+// KnownFacts returns what the player has learned, one fact per discovered clue.
+func (gs *GameState) KnownFacts() []string {
+	facts := make([]string, 0, len(gs.Evidence))
+	for _, ev := range gs.Evidence {
+		facts = append(facts, ev.Details)
+	}
+	return facts
+}
+
+// LocationNotes returns what the narrator needs to know about the current
+// location. It never contains a secret.
+func (gs *GameState) LocationNotes() string {
+	return locationNotes[gs.Location]
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHiddenSecrets(t *testing.T) {
+	gs := NewGameState()
+	if got := len(gs.HiddenSecrets()); got != 4 {
+		t.Fatalf("HiddenSecrets() at start has %d values, want 4", got)
+	}
+
+	gs.discover("frozen_scan")
+	if slices.Contains(gs.HiddenSecrets(), "8675309") {
+		t.Error("locker code still hidden after finding the frozen scan")
+	}
+	if !slices.Contains(gs.HiddenSecrets(), "4711") {
+		t.Error("safe code revealed before finding the inventory")
+	}
+}
+
+func TestLocationNotesKeepSecrets(t *testing.T) {
+	for _, loc := range Locations {
+		notes := strings.ToLower(locationNotes[loc])
+		for _, secret := range Secrets {
+			for _, value := range secret.Values {
+				if strings.Contains(notes, strings.ToLower(value)) {
+					t.Errorf("notes for %s contain %s value %q", loc.ID(), secret.ID, value)
+				}
+			}
+		}
+	}
+}
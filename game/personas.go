@@ -0,0 +1,117 @@
+package game
+
+import (
+	"slices"
+	"strings"
+)
+
+// --- Personas ---
+
+// PersonaFact is something a character will bring up, once the player has
+// found the clue that makes it worth mentioning
+type PersonaFact struct {
+	Text  string
+	After string // Clue ID; empty means from the start
+}
+
+// Persona tells the narrator how a character speaks and what they know.
+// Hidden facts are what the character won't admit; a cover story is what
+// they say instead, the same way every time. Neither may contain a code, and
+// anything that gives away the solution must wait for the clue that proves it.
+type Persona struct {
+	Name       string
+	Role       string
+	Location   Location
+	Voice      string
+	Knows      []PersonaFact
+	Hides      []PersonaFact
+	CoverStory string
+	Threatens  []string // Clue IDs that make the character wary of the player
+}
+
+// Personas lists the characters the player can talk to.
+var Personas = []Persona{
+	{
+		Name:     "Brenda",
+		Role:     "night-shift stocker",
+		Location: LocRegister,
+		Voice:    "Warm but frayed. Talks fast when scared, calls the player 'hon', and keeps glancing at Gary.",
+		Knows: []PersonaFact{
+			{Text: "Dale was her friend; they took their breaks together by the loading dock."},
+			{Text: "Gary went off toward the back of the store a few minutes before the lights died."},
+			{Text: "Dale had been asking her about stock counts that never added up.", After: "skimming_notes"},
+			{Text: "Only Gary has the combination to the office safe.", After: "emergency_procedure"},
+		},
+		Hides: []PersonaFact{
+			{Text: "Something about Gary has bothered her for weeks, but she is too frightened to say what while he can hear."},
+		},
+		Threatens: []string{"skimming_notes"},
+	},
+	{
+		Name:     "Gary",
+		Role:     "store manager",
+		Location: LocRegister,
+		Voice:    "Clipped and managerial. Reaches for policy and procedure, turns questions back on the player, and turns cold when asked where he was.",
+		Knows: []PersonaFact{
+			{Text: "The doors lock magnetically when the power fails; the override is at the breaker panel."},
+		},
+		Hides: []PersonaFact{
+			{Text: "He knows the office safe combination and won't share it."},
+			{Text: "He killed Dale to keep his skimming quiet, and now denies it flatly.", After: "confession"},
+		},
+		CoverStory: "He was in the break room doing paperwork when the power failed, and he barely knew Dale's rounds.",
+		Threatens:  []string{"puncture_wound", "skimming_notes", "override_key"},
+	},
+}
+
+// FindPersona looks up a character by name, ignoring case.
+func FindPersona(name string) (Persona, bool) {
+	for _, persona := range Personas {
+		if strings.EqualFold(persona.Name, strings.TrimSpace(name)) {
+			return persona, true
+		}
+	}
+	return Persona{}, false
+}
+
+// PersonaFacts returns what a character will talk about given the clues found so far.
+func (gs *GameState) PersonaFacts(persona Persona) []string {
+	return gs.factsFound(persona.Knows)
+}
+
+// PersonaHides returns what a character is guarding given the clues found so far.
+func (gs *GameState) PersonaHides(persona Persona) []string {
+	return gs.factsFound(persona.Hides)
+}
+
+// factsFound returns the text of each fact whose clue has been found
+func (gs *GameState) factsFound(all []PersonaFact) []string {
+	facts := []string{}
+	for _, fact := range all {
+		if fact.After == "" || gs.HasEvidence(fact.After) {
+			facts = append(facts, fact.Text)
+		}
+	}
+	return facts
+}
+
+// Suspicion describes how a character feels about the player right now.
+func (gs *GameState) Suspicion(persona Persona) string {
+	if gs.Accused == persona.Name {
+		return "Hostile. The player has accused them of murder."
+	}
+	worry := len(gs.EvidenceAgainst(persona.Name))
+	for _, ev := range gs.Evidence {
+		if slices.Contains(persona.Threatens, ev.ID) {
+			worry++
+		}
+	}
+	switch {
+	case worry == 0:
+		return "Relaxed. The player is just another stranded shopper."
+	case worry < 3:
+		return "Wary. The player is asking too many questions."
+	default:
+		return "Nervous. The player is getting close to something."
+	}
+}
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPersonasKeepSecrets(t *testing.T) {
+	for _, persona := range Personas {
+		text := []string{persona.Voice, persona.CoverStory}
+		for _, fact := range append(slices.Clone(persona.Knows), persona.Hides...) {
+			text = append(text, fact.Text)
+		}
+		all := strings.ToLower(strings.Join(text, " "))
+		for _, secret := range Secrets {
+			for _, value := range secret.Values {
+				if strings.Contains(all, strings.ToLower(value)) {
+					t.Errorf("persona %s contains %s value %q", persona.Name, secret.ID, value)
+				}
+			}
+		}
+	}
+}
+
+func TestPersonasAreSuspects(t *testing.T) {
+	for _, suspect := range Suspects {
+		if _, ok := FindPersona(suspect); !ok {
+			t.Errorf("suspect %s has no persona", suspect)
+		}
+	}
+	killer, _ := FindPersona(Killer)
+	if killer.CoverStory == "" {
+		t.Error("the killer needs a cover story to stick to")
+	}
+}
+
+func TestPersonaFacts(t *testing.T) {
+	brenda, _ := FindPersona("brenda")
+	gs := NewGameState()
+	before := len(gs.PersonaFacts(brenda))
+
+	gs.discover("skimming_notes")
+	if got := len(gs.PersonaFacts(brenda)); got != before+1 {
+		t.Errorf("PersonaFacts() after finding Dale's notes has %d facts, want %d", got, before+1)
+	}
+}
+
+func TestPersonaHidesWaitForConfession(t *testing.T) {
+	gary, _ := FindPersona(Killer)
+	gs := NewGameState()
+	for _, def := range ClueCatalog {
+		if def.ID != "confession" {
+			gs.discover(def.ID)
+		}
+	}
+	for _, hidden := range gs.PersonaHides(gary) {
+		if strings.Contains(hidden, "Dale") {
+			t.Errorf("PersonaHides() before the confession = %q, want no word of Dale", hidden)
+		}
+	}
+
+	gs.discover("confession")
+	if got := strings.Join(gs.PersonaHides(gary), " "); !strings.Contains(got, "killed Dale") {
+		t.Errorf("PersonaHides() after the confession = %q, want the guilt line", got)
+	}
+}
+
+func TestSuspicion(t *testing.T) {
+	gary, _ := FindPersona("Gary")
+	tests := []struct {
+		name     string
+		clues    []string
+		accused  string
+		expected string
+	}{
+		{name: "start", expected: "Relaxed"},
+		{name: "one worrying clue", clues: []string{"puncture_wound"}, expected: "Wary"},
+		{name: "closing in", clues: []string{"puncture_wound", "skimming_notes", "override_key"}, expected: "Nervous"},
+		{name: "accused", accused: "Gary", expected: "Hostile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			for _, clue := range tt.clues {
+				gs.discover(clue)
+			}
+			gs.Accused = tt.accused
+			if got := gs.Suspicion(gary); !strings.HasPrefix(got, tt.expected) {
+				t.Errorf("Suspicion() = %q, want it to start with %q", got, tt.expected)
+			}
+		})
+	}
+}
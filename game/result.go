@@ -0,0 +1,49 @@
+package game
+
+import "strings"
+
+// --- Command Results ---
+
+// MessageKind says what sort of feedback a message is, so frontends can
+// present each kind differently
+type MessageKind int
+
+const (
+	MessageInfo   MessageKind = iota // Ordinary feedback on a command
+	MessageStory                     // A story beat, like the confrontation
+	MessageError                     // The command couldn't be carried out
+	MessagePrompt                    // The game is asking the player for a code
+)
+
+// EscapeNarrative is told when the player escapes the store.
+const EscapeNarrative = "You shove the heavy door open and slip out into the fierce storm. Sirens approach...\n\nYou escaped the Blackout Nightmare!"
+
+// Message is one piece of feedback for the player.
+type Message struct {
+	Kind MessageKind
+	Text string
+}
+
+// Result is everything a command produced.
+type Result struct {
+	Handled  bool      // False if the command wasn't understood and could go to the LLM
+	Messages []Message // Feedback, in the order it happened
+	Events   []Event   // Changes to the game world, in the order they happened
+	Prompt   string    // Code the game now needs: "locker_code", "safe_code", "breaker_code" or ""
+}
+
+// Text joins the messages into a single block of text.
+func (r Result) Text() string {
+	texts := make([]string, len(r.Messages))
+	for i, msg := range r.Messages {
+		texts[i] = msg.Text
+	}
+	return strings.Join(texts, "\n")
+}
+
+// say adds a message to the result of the command being handled
+func (gs *GameState) say(kind MessageKind, text string) {
+	if gs.out != nil {
+		gs.out.Messages = append(gs.out.Messages, Message{Kind: kind, Text: text})
+	}
+}
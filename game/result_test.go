@@ -0,0 +1,81 @@
+package game
+
+import "testing"
+
+func TestHandleCommandResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func(gs *GameState)
+		input          string
+		expectedKinds  []MessageKind
+		expectedPrompt string
+		expectedEvents int
+	}{
+		{
+			name:           "move",
+			input:          "go security",
+			expectedKinds:  []MessageKind{MessageInfo},
+			expectedEvents: 2, // Entered the station, found the wound
+		},
+		{
+			name:          "unknown verb",
+			input:         "dance",
+			expectedKinds: []MessageKind{MessageError},
+		},
+		{
+			name:           "asks for a code",
+			setup:          func(gs *GameState) { gs.Location = LocManagersOffice },
+			input:          "use 4711",
+			expectedKinds:  []MessageKind{MessagePrompt},
+			expectedPrompt: "safe_code",
+		},
+		{
+			name: "escape",
+			setup: func(gs *GameState) {
+				gs.Location = LocLoadingDock
+				gs.Clues["door_unlocked"] = "true"
+			},
+			input:          "escape",
+			expectedKinds:  []MessageKind{MessageStory},
+			expectedEvents: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			if tt.setup != nil {
+				tt.setup(gs)
+			}
+			result := gs.HandleCommand(tt.input)
+
+			if len(result.Messages) != len(tt.expectedKinds) {
+				t.Fatalf("Messages = %+v, want kinds %v", result.Messages, tt.expectedKinds)
+			}
+			for i, msg := range result.Messages {
+				if msg.Kind != tt.expectedKinds[i] {
+					t.Errorf("Messages[%d].Kind = %v, want %v", i, msg.Kind, tt.expectedKinds[i])
+				}
+			}
+			if result.Prompt != tt.expectedPrompt {
+				t.Errorf("Prompt = %q, want %q", result.Prompt, tt.expectedPrompt)
+			}
+			if len(result.Events) != tt.expectedEvents {
+				t.Errorf("Events = %v, want %d events", result.Events, tt.expectedEvents)
+			}
+		})
+	}
+}
+
+func TestCancelInput(t *testing.T) {
+	gs := NewGameState()
+	gs.InputRequired = "safe_code"
+
+	result := gs.CancelInput()
+	if gs.InputRequired != "" || result.Prompt != "" {
+		t.Errorf("InputRequired = %q, Prompt = %q after cancel, want both empty", gs.InputRequired, result.Prompt)
+	}
+	if result.Text() == "" {
+		t.Error("CancelInput() returned no message")
+	}
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"slices"
+	"strings"
+)
+
+// --- Command Routing ---
+
+// Route says who should answer a line the player typed
+type Route int
+
+const (
+	RouteEngine   Route = iota // HandleCommand deals with it
+	RouteNarrator              // The LLM narrates it, when one is available
+	RouteNotebook              // The frontend shows the clue notebook
+)
+
+// engineVerbs are handled by HandleCommand whatever else is available
+var engineVerbs = []string{"go", "g", "take", "t", "inventory", "i", "inv", "help", "h", "escape", "accuse", "hint"}
+
+// RouteCommand decides who answers the input, so every frontend splits
+// commands between the engine and the LLM the same way. Without an LLM,
+// narrator routes should go to HandleCommand, which has basic fallbacks.
+func (gs *GameState) RouteCommand(input string) Route {
+	// Codes always go to the lock that asked for them
+	if gs.InputRequired != "" {
+		return RouteEngine
+	}
+
+	parts := strings.Fields(strings.ToLower(input))
+	if len(parts) == 0 {
+		return RouteEngine
+	}
+	verb := parts[0]
+
+	switch {
+	case IsMovementCommand(verb):
+		return RouteEngine // Bare directions and "back" are moves
+	case verb == "notebook" || verb == "clues" || verb == "board":
+		return RouteNotebook
+	case slices.Contains(engineVerbs, verb):
+		return RouteEngine
+	case verb == "use" || verb == "u":
+		// Puzzle-critical uses need Go logic, anything else is flavor
+		if gs.IsCriticalUse(input) {
+			return RouteEngine
+		}
+		return RouteNarrator
+	default:
+		// Look, examine, talk and unknown verbs are all better narrated
+		return RouteNarrator
+	}
+}
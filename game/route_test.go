@@ -0,0 +1,44 @@
+package game
+
+import "testing"
+
+func TestRouteCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		setup    func(gs *GameState)
+		expected Route
+	}{
+		{name: "movement", input: "go security", expected: RouteEngine},
+		{name: "bare direction", input: "N", expected: RouteEngine},
+		{name: "hint", input: "hint", expected: RouteEngine},
+		{name: "notebook", input: "notebook", expected: RouteNotebook},
+		{name: "examine", input: "examine voucher", expected: RouteNarrator},
+		{name: "unknown verb", input: "dance wildly", expected: RouteNarrator},
+		{name: "flavor use", input: "use voucher", expected: RouteNarrator},
+		{
+			name:     "critical use",
+			input:    "use 4711",
+			setup:    func(gs *GameState) { gs.Location = LocManagersOffice },
+			expected: RouteEngine,
+		},
+		{
+			name:     "code entry",
+			input:    "look",
+			setup:    func(gs *GameState) { gs.InputRequired = "safe_code" },
+			expected: RouteEngine,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewGameState()
+			if tt.setup != nil {
+				tt.setup(gs)
+			}
+			if got := gs.RouteCommand(tt.input); got != tt.expected {
+				t.Errorf("RouteCommand(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// --- Scoring ---
+
+// Points awarded or deducted for each part of a playthrough
+const (
+	pointsEscaped        = 500
+	pointsPerClue        = 25
+	pointsRightAccusal   = 150
+	pointsWrongAccusal   = -100
+	parTurns             = 30 // Turns allowed before the turn penalty starts
+	pointsPerExtraTurn   = -5
+	pointsPerHint        = -30
+	pointsPerWrongCode   = -20
+	pointsPerLLMCall     = -2
+	maxTimeBonus         = 150
+	secondsPerBonusPoint = 4 // The time bonus shrinks by one point every few seconds
+)
+
+// ScoreLine is a single row of the score breakdown.
+type ScoreLine struct {
+	Label  string
+	Detail string
+	Points int
+}
+
+// Score is the breakdown and total for a playthrough.
+type Score struct {
+	Lines []ScoreLine
+	Total int
+}
+
+// Now returns the current time for stats and timestamps. Tests replace it to
+// get reproducible output.
+var Now = time.Now
+
+// RecordNarratedTurn counts a command that was handed to the LLM instead of
+// HandleCommand.
+func (gs *GameState) RecordNarratedTurn() {
+	gs.Stats.Turns++
+	gs.Stats.LLMCalls++
+}
+
+// Elapsed returns how long the game has been (or was) played.
+func (gs *GameState) Elapsed() time.Duration {
+	if gs.Stats.StartedAt.IsZero() {
+		return 0
+	}
+	end := gs.Stats.EndedAt
+	if end.IsZero() {
+		end = Now()
+	}
+	return end.Sub(gs.Stats.StartedAt).Round(time.Second)
+}
+
+// Score works out the player's points from their progress and stats.
+func (gs *GameState) Score() Score {
+	var score Score
+	add := func(label, detail string, points int) {
+		score.Lines = append(score.Lines, ScoreLine{Label: label, Detail: detail, Points: points})
+		score.Total += points
+	}
+
+	if gs.GameOver {
+		add("Escaped", "made it out of the store", pointsEscaped)
+	} else {
+		add("Escaped", "still trapped inside", 0)
+	}
+
+	add("Clues found", fmt.Sprintf("%d of %d", len(gs.Evidence), len(ClueCatalog)), len(gs.Evidence)*pointsPerClue)
+
+	switch gs.Accused {
+	case "":
+		add("Accusation", "nobody accused", 0)
+	case Killer:
+		add("Accusation", "named the killer", pointsRightAccusal)
+	default:
+		add("Accusation", "accused "+gs.Accused, pointsWrongAccusal)
+	}
+
+	extraTurns := max(gs.Stats.Turns-parTurns, 0)
+	add("Turns taken", fmt.Sprintf("%d (par %d)", gs.Stats.Turns, parTurns), extraTurns*pointsPerExtraTurn)
+	add("Hints used", fmt.Sprint(gs.HintsUsed()), gs.HintsUsed()*pointsPerHint)
+	add("Wrong codes", fmt.Sprint(gs.Stats.WrongCodes), gs.Stats.WrongCodes*pointsPerWrongCode)
+
+	elapsed := gs.Elapsed()
+	timeBonus := 0
+	if gs.GameOver {
+		timeBonus = max(maxTimeBonus-int(elapsed.Seconds())/secondsPerBonusPoint, 0)
+	}
+	add("Time elapsed", elapsed.String(), timeBonus)
+	add("Narrator calls", fmt.Sprint(gs.Stats.LLMCalls), gs.Stats.LLMCalls*pointsPerLLMCall)
+
+	score.Total = max(score.Total, 0)
+	return score
+}
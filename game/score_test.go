@@ -0,0 +1,79 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	start := time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		state    *GameState
+		expected int
+	}{
+		{
+			name:     "fresh game",
+			state:    &GameState{Stats: Stats{StartedAt: start, EndedAt: start}},
+			expected: 0,
+		},
+		{
+			name: "fast clean escape",
+			state: &GameState{
+				GameOver: true,
+				Evidence: []Evidence{{}, {}, {}, {}},
+				Accused:  "Gary",
+				Stats:    Stats{Turns: 20, StartedAt: start, EndedAt: start.Add(2 * time.Minute)},
+			},
+			// 500 escape + 4*25 clues + 150 accusation + (150 - 120/4) time
+			expected: 500 + 100 + 150 + 120,
+		},
+		{
+			name: "slow escape with help",
+			state: &GameState{
+				GameOver: true,
+				Hints:    map[string]int{"open_locker": 3},
+				Stats:    Stats{Turns: 40, WrongCodes: 2, LLMCalls: 5, StartedAt: start, EndedAt: start.Add(time.Hour)},
+			},
+			// 500 escape - 10*5 turns - 3*30 hints - 2*20 codes - 5*2 narrator
+			expected: 500 - 50 - 90 - 40 - 10,
+		},
+		{
+			name: "wrong accusation never goes negative",
+			state: &GameState{
+				Accused: "Brenda",
+				Stats:   Stats{StartedAt: start, EndedAt: start},
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := tt.state.Score()
+			if score.Total != tt.expected {
+				t.Errorf("Score().Total = %d, want %d (%+v)", score.Total, tt.expected, score.Lines)
+			}
+		})
+	}
+}
+
+func TestStatsCounting(t *testing.T) {
+	gs := NewGameState()
+	gs.HandleCommand("go security")
+	gs.HandleCommand("go lockers")
+	gs.HandleCommand("use 8675309")
+	gs.HandleCommand("1234")
+	gs.RecordNarratedTurn()
+
+	if gs.Stats.Turns != 5 {
+		t.Errorf("Stats.Turns = %d, want 5", gs.Stats.Turns)
+	}
+	if gs.Stats.WrongCodes != 1 {
+		t.Errorf("Stats.WrongCodes = %d, want 1", gs.Stats.WrongCodes)
+	}
+	if gs.Stats.LLMCalls != 1 {
+		t.Errorf("Stats.LLMCalls = %d, want 1", gs.Stats.LLMCalls)
+	}
+}
@@ -0,0 +1,32 @@
+package game
+
+import (
+	"maps"
+	"slices"
+)
+
+// --- Snapshots ---
+
+// Snapshot returns a deep copy of the game state for code that reads it on
+// another goroutine, such as the LLM narrator, while the game carries on.
+// The copy has no event subscribers; commands handled on it are not seen
+// by anyone.
+func (gs *GameState) Snapshot() *GameState {
+	snapshot := &GameState{
+		Location:      gs.Location,
+		Visited:       maps.Clone(gs.Visited),
+		Trail:         slices.Clone(gs.Trail),
+		Inventory:     maps.Clone(gs.Inventory),
+		Clues:         maps.Clone(gs.Clues),
+		Evidence:      slices.Clone(gs.Evidence),
+		Accused:       gs.Accused,
+		Hints:         maps.Clone(gs.Hints),
+		Stats:         gs.Stats,
+		GameOver:      gs.GameOver,
+		InputRequired: gs.InputRequired,
+	}
+	for i := range snapshot.Evidence {
+		snapshot.Evidence[i].Linked = slices.Clone(snapshot.Evidence[i].Linked)
+	}
+	return snapshot
+}
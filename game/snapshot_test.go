@@ -0,0 +1,43 @@
+package game
+
+import "testing"
+
+func TestSnapshotIsIndependent(t *testing.T) {
+	gs := NewGameState()
+	gs.HandleCommand("n")
+	gs.HandleCommand("take dale's handheld scanner")
+	gs.ToggleLink("frozen_scan", "Gary")
+
+	snapshot := gs.Snapshot()
+	visited := len(snapshot.Visited)
+
+	// Changes to the snapshot stay there, without events
+	events := 0
+	gs.Subscribe(func(Event) { events++ })
+	snapshot.HandleCommand("take crumpled employee discount voucher")
+	if events != 0 {
+		t.Errorf("commands on a snapshot reached %d subscribers of the original", events)
+	}
+	if gs.Inventory[ItemVoucher] {
+		t.Error("taking an item in the snapshot changed the original")
+	}
+
+	// Changes to the original don't reach the snapshot
+	gs.HandleCommand("e")
+	gs.ToggleLink("frozen_scan", "Brenda")
+	gs.Clues["locker_opened"] = "true"
+	if snapshot.Location != LocSecurityStation {
+		t.Errorf("snapshot location = %s, want it to stay at the security station", snapshot.Location.ID())
+	}
+	if len(snapshot.Visited) != visited {
+		t.Errorf("snapshot visited %d locations, want %d", len(snapshot.Visited), visited)
+	}
+	if _, ok := snapshot.Clues["locker_opened"]; ok {
+		t.Error("new clue in the original showed up in the snapshot")
+	}
+	for _, ev := range snapshot.Evidence {
+		if len(ev.Linked) > 1 {
+			t.Errorf("linking a suspect in the original changed snapshot evidence %s: %v", ev.ID, ev.Linked)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package game
 
+import "time"
+
 // --- Game State Definitions ---
 
 // Location represents a distinct area within the game world
@@ -26,24 +28,44 @@ const (
 	ItemOverrideKey Item = "Manual Override Key"
 )
 
+// Stats tracks how the player is doing, for scoring
+type Stats struct {
+	Turns      int // Commands entered, including ones narrated by the LLM
+	WrongCodes int // Incorrect codes tried on locks
+	LLMCalls   int // Commands handed to the LLM narrator
+	StartedAt  time.Time
+	EndedAt    time.Time
+}
+
 // GameState represents the current state of the game
 type GameState struct {
 	// Game state
 	Location      Location
+	Visited       map[Location]bool // Locations the player has been to
+	Trail         []Location        // Locations the player has left, most recent last
 	Inventory     map[Item]bool
 	Clues         map[string]string // Store discovered codes/facts
+	Evidence      []Evidence        // Clues for the clue board, in order found
+	Accused       string            // Suspect the player has accused, if any
+	Hints         map[string]int    // Hints given per puzzle ID
+	Stats         Stats             // Counters for the end-of-game report
 	GameOver      bool
-	Message       string // Feedback/narrative display
-	CurrentInput  string
 	InputRequired string // Specific input needed: "locker_code", "safe_code", "breaker_code"
+
+	out              *Result      // Result of the command being handled, if any
+	subscribers      []subscriber // Event handlers, see Subscribe
+	nextSubscriberID int
 }
 
 // NewGameState initializes and returns a new game state
 func NewGameState() *GameState {
 	return &GameState{
 		Location:  LocRegister,
+		Visited:   map[Location]bool{LocRegister: true},
 		Inventory: make(map[Item]bool),
 		Clues:     make(map[string]string),
+		Hints:     make(map[string]int),
+		Stats:     Stats{StartedAt: Now()},
 		GameOver:  false,
 	}
 }
@@ -0,0 +1,227 @@
+package game
+
+import "strings"
+
+// --- World Topology ---
+
+// Locations lists every location in the order they appear in the story.
+var Locations = []Location{
+	LocRegister,
+	LocSecurityStation,
+	LocLockerArea,
+	LocManagersOffice,
+	LocLoadingDock,
+	LocEscaped,
+}
+
+// Exit describes a path the player can take with the 'go' command.
+type Exit struct {
+	From           Location
+	To             Location
+	Direction      string   // Compass direction, e.g. "north"
+	Keywords       []string // Substrings of the destination that select this exit
+	RequiresClue   string   // Clue that must be known before the exit can be used
+	Message        string   // Narration when the player takes the exit
+	BlockedMessage string   // Narration when the required clue is missing
+}
+
+// Exits holds every path between locations. Exits from the same location are
+// matched in order, so more specific keywords must come first.
+var Exits = []Exit{
+	{
+		From:      LocRegister,
+		To:        LocSecurityStation,
+		Direction: "north",
+		Keywords:  []string{"security", "electronics", "back", "scream"},
+		Message:   "You hurry towards the back of the store, near the electronics section where the scream came from.",
+	},
+	{
+		From:      LocSecurityStation,
+		To:        LocLockerArea,
+		Direction: "east",
+		Keywords:  []string{"locker"},
+		Message:   "You move towards the nearby employee lockers, focusing on Dale's.",
+	},
+	{
+		From:      LocSecurityStation,
+		To:        LocManagersOffice,
+		Direction: "west",
+		Keywords:  []string{"office", "manager"},
+		Message:   "You head towards the small manager's office behind the customer service area.",
+	},
+	{
+		From:           LocSecurityStation,
+		To:             LocLoadingDock,
+		Direction:      "north",
+		Keywords:       []string{"loading", "dock", "breaker"},
+		RequiresClue:   "map_details",
+		Message:        "Following the crude map from Dale's notebook, you find the loading dock area.",
+		BlockedMessage: "You aren't sure exactly where the loading dock or the specific breaker panel is.",
+	},
+	{
+		From:      LocSecurityStation,
+		To:        LocRegister,
+		Direction: "south",
+		Keywords:  []string{"register", "front"},
+		Message:   "You head back towards the front registers.",
+	},
+	{
+		From:      LocLockerArea,
+		To:        LocSecurityStation,
+		Direction: "west",
+		Keywords:  []string{"security"},
+		Message:   "You step away from the lockers and back to the main security station area.",
+	},
+	{
+		From:      LocManagersOffice,
+		To:        LocSecurityStation,
+		Direction: "east",
+		Keywords:  []string{"security", "customer service"},
+		Message:   "You leave the manager's office, heading back towards the security station.",
+	},
+	{
+		From:           LocManagersOffice,
+		To:             LocLoadingDock,
+		Direction:      "northeast",
+		Keywords:       []string{"loading", "dock", "breaker"},
+		RequiresClue:   "map_details",
+		Message:        "You head from the office towards the loading dock, following the map's directions.",
+		BlockedMessage: "You don't know the specific route to the loading dock from here without the map details.",
+	},
+	{
+		From:      LocLoadingDock,
+		To:        LocManagersOffice,
+		Direction: "southwest",
+		Keywords:  []string{"office"},
+		Message:   "You head back towards the manager's office area.",
+	},
+	{
+		From:      LocLoadingDock,
+		To:        LocSecurityStation,
+		Direction: "south",
+		Keywords:  []string{"security"},
+		Message:   "You move back towards the main security station area.",
+	},
+}
+
+// directionAliases maps compass words and abbreviations to a direction
+var directionAliases = map[string]string{
+	"n": "north", "north": "north",
+	"s": "south", "south": "south",
+	"e": "east", "east": "east",
+	"w": "west", "west": "west",
+	"ne": "northeast", "northeast": "northeast",
+	"nw": "northwest", "northwest": "northwest",
+	"se": "southeast", "southeast": "southeast",
+	"sw": "southwest", "southwest": "southwest",
+}
+
+// ParseDirection resolves a compass word or abbreviation ("n", "north").
+func ParseDirection(word string) (string, bool) {
+	direction, ok := directionAliases[strings.ToLower(strings.TrimSpace(word))]
+	return direction, ok
+}
+
+// IsMovementCommand reports whether the input is a bare movement command
+// such as "n", "southwest", "back" or "return".
+func IsMovementCommand(input string) bool {
+	verb := strings.ToLower(strings.TrimSpace(input))
+	if _, ok := ParseDirection(verb); ok {
+		return true
+	}
+	return verb == "back" || verb == "return"
+}
+
+// Placement describes where an item can be picked up.
+type Placement struct {
+	Item          Item
+	Location      Location
+	RequiresClue  string // Clue that must be set before the item is reachable
+	LockedMessage string // Feedback when trying to take the item too early
+}
+
+// Placements lists every item that can be taken, in display order.
+var Placements = []Placement{
+	{Item: ItemVoucher, Location: LocSecurityStation},
+	{Item: ItemScanner, Location: LocSecurityStation},
+	{Item: ItemNotebook, Location: LocLockerArea, RequiresClue: "locker_opened", LockedMessage: "The locker needs to be open first."},
+	{Item: ItemCard, Location: LocManagersOffice},
+	{Item: ItemInventory, Location: LocManagersOffice},
+	{Item: ItemOverrideKey, Location: LocManagersOffice, RequiresClue: "safe_opened", LockedMessage: "The safe needs to be open first."},
+}
+
+// ExitsFrom returns the exits leading out of a location, in match order.
+func ExitsFrom(loc Location) []Exit {
+	exits := []Exit{}
+	for _, exit := range Exits {
+		if exit.From == loc {
+			exits = append(exits, exit)
+		}
+	}
+	return exits
+}
+
+// OneWayExits returns the exits that have no matching exit leading back.
+func OneWayExits() []Exit {
+	oneWay := []Exit{}
+	for _, exit := range Exits {
+		returns := false
+		for _, back := range Exits {
+			if back.From == exit.To && back.To == exit.From {
+				returns = true
+				break
+			}
+		}
+		if !returns {
+			oneWay = append(oneWay, exit)
+		}
+	}
+	return oneWay
+}
+
+// FindItem looks up an item by its full name, ignoring case.
+func FindItem(name string) (Item, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, placement := range Placements {
+		if strings.ToLower(string(placement.Item)) == name {
+			return placement.Item, true
+		}
+	}
+	return "", false
+}
+
+// hasClue reports whether a clue has been discovered. An empty clue is always known.
+func (gs *GameState) hasClue(clue string) bool {
+	if clue == "" {
+		return true
+	}
+	_, found := gs.Clues[clue]
+	return found
+}
+
+// HasVisited reports whether the player has been to a location.
+func (gs *GameState) HasVisited(loc Location) bool {
+	return gs.Visited[loc] || gs.Location == loc
+}
+
+// IsKnown reports whether the player knows a location exists, either by having
+// been there or by seeing an open route to it from somewhere they have been.
+func (gs *GameState) IsKnown(loc Location) bool {
+	if gs.HasVisited(loc) {
+		return true
+	}
+	for _, exit := range Exits {
+		if exit.To == loc && gs.HasVisited(exit.From) && gs.hasClue(exit.RequiresClue) {
+			return true
+		}
+	}
+	return false
+}
+
+// markVisited records that the player has entered a location.
+func (gs *GameState) markVisited(loc Location) {
+	if gs.Visited == nil {
+		gs.Visited = make(map[Location]bool)
+	}
+	gs.Visited[loc] = true
+}
@@ -0,0 +1,73 @@
+package game
+
+import "testing"
+
+func TestIsKnown(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    *GameState
+		location Location
+		expected bool
+	}{
+		{
+			name:     "current location",
+			state:    NewGameState(),
+			location: LocRegister,
+			expected: true,
+		},
+		{
+			name:     "neighbour of a visited location",
+			state:    NewGameState(),
+			location: LocSecurityStation,
+			expected: true,
+		},
+		{
+			name:     "two rooms away",
+			state:    NewGameState(),
+			location: LocManagersOffice,
+			expected: false,
+		},
+		{
+			name: "loading dock before the map clue",
+			state: &GameState{
+				Location: LocManagersOffice,
+				Visited:  map[Location]bool{LocRegister: true, LocSecurityStation: true, LocManagersOffice: true},
+				Clues:    map[string]string{},
+			},
+			location: LocLoadingDock,
+			expected: false,
+		},
+		{
+			name: "loading dock after the map clue",
+			state: &GameState{
+				Location: LocManagersOffice,
+				Visited:  map[Location]bool{LocRegister: true, LocSecurityStation: true, LocManagersOffice: true},
+				Clues:    map[string]string{"map_details": "true"},
+			},
+			location: LocLoadingDock,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.state.IsKnown(tt.location)
+			if result != tt.expected {
+				t.Errorf("IsKnown(%s) = %v, want %v", tt.location.ID(), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGoMarksVisited(t *testing.T) {
+	gs := &GameState{
+		Location:  LocRegister,
+		Inventory: make(map[Item]bool),
+		Clues:     make(map[string]string),
+	}
+	gs.HandleCommand("go security")
+
+	if !gs.Visited[LocSecurityStation] {
+		t.Errorf("Visited[%s] = false after moving there", LocSecurityStation.ID())
+	}
+}
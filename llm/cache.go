@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// --- Response Cache ---
+
+// Cache remembers replies so the same prompt isn't paid for twice. Entries
+// are keyed by the normalized prompt, the model and the prompt template
+// version, so changing either starts afresh.
+type Cache struct {
+	Next    Generator // Asked on a miss
+	Model   string
+	Version string
+	Dir     string // Keeps replies between runs; empty keeps them in memory only
+
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+// NewCache wraps a generator with a cache
+func NewCache(next Generator, model, version, dir string) *Cache {
+	return &Cache{Next: next, Model: model, Version: version, Dir: dir, entries: map[string]string{}}
+}
+
+// UseCache puts a response cache in front of the client's generator. Replies
+// are also kept in dir if it isn't empty.
+func (c *Client) UseCache(dir string) {
+	c.Generator = NewCache(c.Generator, c.ModelName, c.prompts().Version, dir)
+}
+
+// Generate returns the cached reply for a prompt, asking Next on a miss
+func (c *Cache) Generate(ctx context.Context, prompt string) (Reply, error) {
+	key := cacheKey(c.Model, c.Version, prompt)
+	if text, ok := c.lookup(key); ok {
+		log.Printf("LLM cache hit %s", key[:12])
+		return Reply{Text: text}, nil
+	}
+
+	reply, err := c.Next.Generate(ctx, prompt)
+	if err != nil {
+		return Reply{}, err
+	}
+	c.store(key, reply.Text)
+	return reply, nil
+}
+
+// lookup checks memory, then the cache directory
+func (c *Cache) lookup(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reply, ok := c.entries[key]; ok {
+		return reply, true
+	}
+	if c.Dir == "" {
+		return "", false
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.Dir, key+".txt"))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Error reading LLM cache: %v", err)
+		}
+		return "", false
+	}
+	c.entries[key] = string(data)
+	return string(data), true
+}
+
+// store keeps a reply in memory and, if there is one, the cache directory
+func (c *Cache) store(key, reply string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = reply
+	if c.Dir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		log.Printf("Error creating LLM cache directory: %v", err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(c.Dir, key+".txt"), []byte(reply), 0644); err != nil {
+		log.Printf("Error writing LLM cache: %v", err)
+	}
+}
+
+// cacheKey identifies a prompt for a model and template version. Case and
+// runs of whitespace in the prompt don't matter.
+func cacheKey(model, version, prompt string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(prompt), " "))
+	sum := sha256.Sum256([]byte(model + "\x00" + version + "\x00" + normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// echoGenerator answers each prompt with itself and counts the calls
+type echoGenerator struct {
+	calls int
+	err   error
+}
+
+func (e *echoGenerator) Generate(ctx context.Context, prompt string) (Reply, error) {
+	e.calls++
+	if e.err != nil {
+		return Reply{}, e.err
+	}
+	return Reply{Text: "reply to " + prompt, Usage: Usage{PromptTokens: 10, ResponseTokens: 5}}, nil
+}
+
+func TestCacheKey(t *testing.T) {
+	base := cacheKey("gemini-2.0-flash", "3", "Look around")
+	tests := []struct {
+		name  string
+		key   string
+		equal bool
+	}{
+		{"same prompt", cacheKey("gemini-2.0-flash", "3", "Look around"), true},
+		{"case and spacing", cacheKey("gemini-2.0-flash", "3", "  look\n\tAROUND "), true},
+		{"different prompt", cacheKey("gemini-2.0-flash", "3", "Look up"), false},
+		{"different model", cacheKey("gemini-2.5-pro", "3", "Look around"), false},
+		{"different template version", cacheKey("gemini-2.0-flash", "4", "Look around"), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.key == base; got != tt.equal {
+			t.Errorf("%s: keys equal = %v, want %v", tt.name, got, tt.equal)
+		}
+	}
+}
+
+func TestCacheInMemory(t *testing.T) {
+	next := &echoGenerator{}
+	cache := NewCache(next, "model", "1", "")
+	ctx := context.Background()
+
+	first, _ := cache.Generate(ctx, "Look around")
+	second, _ := cache.Generate(ctx, "look  around")
+	if first.Text != second.Text {
+		t.Errorf("cached reply = %q, want %q", second.Text, first.Text)
+	}
+	if second.Usage.Total() != 0 {
+		t.Errorf("cache hit billed %d tokens, want 0", second.Usage.Total())
+	}
+	if next.calls != 1 {
+		t.Errorf("generator called %d times, want 1", next.calls)
+	}
+
+	cache.Generate(ctx, "Look up")
+	if next.calls != 2 {
+		t.Errorf("generator called %d times after a new prompt, want 2", next.calls)
+	}
+}
+
+func TestCacheOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	first := &echoGenerator{}
+	if _, err := NewCache(first, "model", "1", dir).Generate(ctx, "Look around"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new run finds the reply on disk
+	second := &echoGenerator{}
+	got, err := NewCache(second, "model", "1", dir).Generate(ctx, "Look around")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != "reply to Look around" || second.calls != 0 {
+		t.Errorf("Generate() = %+v after %d calls, want the stored reply without calling", got, second.calls)
+	}
+
+	// Until the templates change
+	NewCache(second, "model", "2", dir).Generate(ctx, "Look around")
+	if second.calls != 1 {
+		t.Errorf("generator called %d times after a version change, want 1", second.calls)
+	}
+}
+
+func TestCacheSkipsErrors(t *testing.T) {
+	next := &echoGenerator{err: errors.New("quota")}
+	cache := NewCache(next, "model", "1", "")
+	ctx := context.Background()
+
+	if _, err := cache.Generate(ctx, "Look around"); err == nil {
+		t.Fatal("Generate() hid the generator's error")
+	}
+	next.err = nil
+	if got, _ := cache.Generate(ctx, "Look around"); got.Text != "reply to Look around" {
+		t.Errorf("Generate() = %+v, want a fresh reply after the failure", got)
+	}
+}
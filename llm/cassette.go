@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// --- Cassettes ---
+
+// CassetteMode says whether a cassette is being written or played back
+type CassetteMode int
+
+const (
+	CassetteRecord CassetteMode = iota // Call the LLM and save every exchange
+	CassetteReplay                     // Serve saved exchanges without the LLM
+)
+
+// ErrNotRecorded is returned when replaying a prompt the cassette doesn't have
+var ErrNotRecorded = errors.New("prompt not on the cassette")
+
+// Interaction is one prompt and reply saved on a cassette
+type Interaction struct {
+	Key      string `json:"key"`
+	Model    string `json:"model"`
+	Version  string `json:"version"`
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+}
+
+// Cassette records LLM exchanges to a file and plays them back, so tests
+// and demos give the same narration every time, offline
+type Cassette struct {
+	Path    string
+	Mode    CassetteMode
+	Next    Generator // Asked while recording; unused on replay
+	Model   string
+	Version string
+
+	mu           sync.Mutex
+	Interactions []Interaction
+}
+
+// RecordCassette starts a new cassette at path, replacing any old one
+func RecordCassette(path string, next Generator, model, version string) *Cassette {
+	return &Cassette{Path: path, Mode: CassetteRecord, Next: next, Model: model, Version: version}
+}
+
+// ReplayCassette loads a cassette recorded earlier
+func ReplayCassette(path, model, version string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{Path: path, Mode: CassetteReplay, Model: model, Version: version}
+	if err := json.Unmarshal(data, &cassette.Interactions); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// Record saves every exchange with the LLM to a cassette at path
+func (c *Client) Record(path string) {
+	c.Generator = RecordCassette(path, c.Generator, c.ModelName, c.prompts().Version)
+	log.Printf("Recording LLM exchanges to %s", path)
+}
+
+// Replay answers from a cassette instead of the LLM. It needs no API key.
+func (c *Client) Replay(path string) error {
+	cassette, err := ReplayCassette(path, c.ModelName, c.prompts().Version)
+	if err != nil {
+		return err
+	}
+	c.Generator = cassette
+	c.Enabled = true
+	log.Printf("Replaying LLM exchanges from %s", path)
+	return nil
+}
+
+// Generate replays the saved reply for a prompt, or records a new one
+func (c *Cassette) Generate(ctx context.Context, prompt string) (Reply, error) {
+	key := cacheKey(c.Model, c.Version, prompt)
+	if c.Mode == CassetteReplay {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, interaction := range c.Interactions {
+			if interaction.Key == key {
+				return Reply{Text: interaction.Response}, nil
+			}
+		}
+		return Reply{}, ErrNotRecorded
+	}
+
+	reply, err := c.Next.Generate(ctx, prompt)
+	if err != nil {
+		return Reply{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, Interaction{Key: key, Model: c.Model, Version: c.Version, Prompt: prompt, Response: reply.Text})
+	// Saved after every exchange so a crash doesn't lose the recording
+	if err := c.save(); err != nil {
+		log.Printf("Error saving cassette %s: %v", c.Path, err)
+	}
+	return reply, nil
+}
+
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(c.Interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, data, 0644)
+}
@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCassetteRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	ctx := context.Background()
+	prompts := []string{"Look around", "Talk Brenda"}
+
+	recorder := RecordCassette(path, &echoGenerator{}, "model", "1")
+	for _, prompt := range prompts {
+		if _, err := recorder.Generate(ctx, prompt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	player, err := ReplayCassette(path, "model", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, prompt := range prompts {
+		got, err := player.Generate(ctx, prompt)
+		if err != nil {
+			t.Fatalf("replaying %q: %v", prompt, err)
+		}
+		if want := "reply to " + prompt; got.Text != want {
+			t.Errorf("replayed %q, want %q", got.Text, want)
+		}
+	}
+
+	if _, err := player.Generate(ctx, "Dance"); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("replaying an unknown prompt gave %v, want ErrNotRecorded", err)
+	}
+}
+
+func TestReplayWithoutAPIKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	gs := playTo()
+
+	recording := &Client{Enabled: true, Generator: &fakeGenerator{reply: "Shelves loom in the dark."}, ModelName: DefaultModel}
+	recording.Record(path)
+	want, err := recording.GenerateResponse(context.Background(), "look around", gs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replaying := &Client{ModelName: DefaultModel}
+	if err := replaying.Replay(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := replaying.GenerateResponse(context.Background(), "look around", gs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("replayed response = %q, want %q", got, want)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if err := (&Client{}).Replay(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Replay() of a missing cassette succeeded")
+	}
+}
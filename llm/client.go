@@ -2,10 +2,12 @@ package llm
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 	"os"
-	"strings"
+	"slices"
+	"sync"
+	"time"
 
 	"blackoutbargain/game"
 
@@ -18,22 +20,40 @@ type Client struct {
 	APIKey         string
 	GeminiClient   *genai.Client
 	GeminiModel    *genai.GenerativeModel
-	Context        context.Context
+	Generator      Generator // Where replies come from; the Gemini model unless a test replaces it
+	ModelName      string    // Part of the cache key
 	Enabled        bool
 	LastPromptSent string
+	Prompts        *Prompts // Narrator prompt templates
+
+	Timeout     time.Duration // Limit for a whole call, retries included; DefaultTimeout if zero
+	MaxAttempts int           // Tries per call for transient errors; 3 if zero
+	RetryDelay  time.Duration // Wait before the first retry, doubled each time; 500ms if zero
+	TokenBudget int           // Tokens the session may use before going offline; 0 for no limit
+
+	mu           sync.Mutex
+	recentEvents []string     // Latest game events, oldest first, for prompt context
+	usage        SessionUsage // Totals so far, see Usage
 }
 
+// DefaultModel is the Gemini model used for narration
+const DefaultModel = "gemini-2.0-flash"
+
+// maxRecentEvents is how many game events are kept for the prompt
+const maxRecentEvents = 5
+
 // New initializes a new LLM client
 func New() *Client {
 	client := &Client{
-		APIKey:  os.Getenv("GEMINI_API_KEY"),
-		Context: context.Background(),
-		Enabled: false,
+		APIKey:    os.Getenv("GEMINI_API_KEY"),
+		ModelName: DefaultModel,
+		Enabled:   false,
+		Prompts:   DefaultPrompts(),
 	}
 
 	// Initialize LLM Client if key exists
 	if client.APIKey != "" {
-		geminiClient, err := genai.NewClient(client.Context, option.WithAPIKey(client.APIKey))
+		geminiClient, err := genai.NewClient(context.Background(), option.WithAPIKey(client.APIKey))
 		if err != nil {
 			log.Printf("Error creating LLM client: %v. LLM disabled.", err)
 			return client
@@ -41,14 +61,10 @@ func New() *Client {
 
 		client.GeminiClient = geminiClient
 		// Use a fast and capable model like Flash
-		client.GeminiModel = geminiClient.GenerativeModel("gemini-2.0-flash")
+		client.GeminiModel = geminiClient.GenerativeModel(client.ModelName)
 		// Set safety settings to block harmful content
-		client.GeminiModel.SafetySettings = []*genai.SafetySetting{
-			{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockMediumAndAbove},
-			{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockMediumAndAbove},
-			{Category: genai.HarmCategorySexuallyExplicit, Threshold: genai.HarmBlockMediumAndAbove},
-			{Category: genai.HarmCategoryDangerousContent, Threshold: genai.HarmBlockMediumAndAbove},
-		}
+		client.SetSafetyThresholds(DefaultSafetyThresholds)
+		client.Generator = &GeminiGenerator{Model: client.GeminiModel}
 		client.Enabled = true
 		log.Println("LLM Client Initialized.")
 	}
@@ -64,78 +80,79 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// GenerateResponse calls the LLM with the provided player input and game state
-func (c *Client) GenerateResponse(playerInput string, gameState *game.GameState) (string, error) {
-	if !c.Enabled || c.GeminiClient == nil || c.GeminiModel == nil {
-		return "LLM support is not available. Using basic descriptions.", nil
+// Observe records a game event so the narrator knows what just happened.
+// Pass it to GameState.Subscribe.
+func (c *Client) Observe(e game.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.recentEvents = append(c.recentEvents, e.String())
+	if len(c.recentEvents) > maxRecentEvents {
+		c.recentEvents = c.recentEvents[len(c.recentEvents)-maxRecentEvents:]
 	}
+}
 
-	// Construct the prompt
-	prompt := c.buildPrompt(playerInput, gameState)
-	c.LastPromptSent = prompt
-
-	// Send the prompt to the LLM
-	resp, err := c.GeminiModel.GenerateContent(c.Context, genai.Text(prompt))
-	if err != nil {
-		log.Printf("LLM API call error: %v", err)
-		return "", fmt.Errorf("API request failed: %w", err)
+// GenerateResponse calls the LLM with the provided player input and game
+// state. The call gives up after the client's timeout or when ctx is
+// canceled; if the LLM fails for any other reason the player gets the
+// offline description instead.
+func (c *Client) GenerateResponse(ctx context.Context, playerInput string, gameState *game.GameState) (string, error) {
+	if !c.Enabled || c.Generator == nil {
+		return "LLM support is not available. Using basic descriptions.", nil
 	}
-
-	// Process the response
-	if len(resp.Candidates) > 0 &&
-		resp.Candidates[0].Content != nil &&
-		len(resp.Candidates[0].Content.Parts) > 0 {
-		generatedText := fmt.Sprintf("%v", resp.Candidates[0].Content.Parts[0])
-		// Simple cleanup: remove potential markdown emphasis added by LLM if not desired
-		generatedText = strings.ReplaceAll(generatedText, "*", "")
-		return generatedText, nil
+	if c.Usage().BudgetSpent() {
+		log.Printf("LLM token budget of %d spent, using the offline description.", c.TokenBudget)
+		c.addCall(0, true)
+		return offlineReply(playerInput, gameState), nil
 	}
 
-	// Handle cases where the LLM response is empty or malformed
-	log.Printf("LLM Warning: Received empty or unexpected response format: %+v", resp)
-	return "The situation doesn't seem to change.", nil
-}
-
-// buildPrompt creates the detailed prompt for the Gemini API
-func (c *Client) buildPrompt(playerInput string, gameState *game.GameState) string {
-	var sb strings.Builder
-
-	// --- System Instructions / Context ---
-	sb.WriteString("You are the narrator for 'Blackout Bargain', a text adventure game. The player is trapped in a dark Superstore after a power failure killed the lights and locked the doors. Dale, the security guard, was found dead (puncture wound, neck). The player is with Brenda (stocker) and Gary (manager). Goal: Escape.")
-	sb.WriteString(" Core Puzzle Path: Find Dale (security station) -> Get Voucher (from Dale) & Scanner -> Use scanner code (8675309) on Locker -> Get Notebook -> Read notebook (mentions Brenda/Gary, 'OVERSTOCK' silent alarm, map to breaker panel needing manager key) -> Go to Manager's Office -> Get Emergency Card (mentions key in safe, code is 'OVERSTOCK' from inventory sheet) -> Get Inventory Sheet -> Use sheet ('OVERSTOCK' -> code 4711) on Safe -> Get Override Key -> Go to Loading Dock Breaker Panel (from map) -> Use Key & 'OVERSTOCK' (or 683778625) on panel -> Unlock door -> Confrontation (Gary is killer) -> Escape.")
-	sb.WriteString(" Rules: Narrate atmospheric outcomes of player actions based on current state. Stick to the established items, characters, and puzzle path. Do NOT invent new major items, characters, bypasses, or solutions. If the player tries something irrelevant or impossible, explain why it fails or gently guide them back to relevant actions based on their known clues/location. Be concise but descriptive. Keep the tone tense/mysterious.")
-
-	// --- Current Game State ---
-	sb.WriteString("\n\n--- Current State ---")
-	sb.WriteString(fmt.Sprintf("\nLocation: %s (%s)", gameState.GetLocationName(), gameState.GetLocationDescription()))
-
-	// Inventory
-	invItems := []string{}
-	for item := range gameState.Inventory {
-		invItems = append(invItems, string(item))
+	// Construct the prompt
+	prompt, action, err := c.buildPrompt(playerInput, gameState)
+	if err != nil {
+		log.Printf("LLM prompt error: %v", err)
+		return "", err
 	}
-	if len(invItems) == 0 {
-		sb.WriteString("\nInventory: Empty")
-	} else {
-		sb.WriteString(fmt.Sprintf("\nInventory: %s", strings.Join(invItems, ", ")))
+	c.mu.Lock()
+	c.LastPromptSent = prompt
+	c.mu.Unlock()
+	log.Printf("Narrator prompt: %s template, version %s (%s)", templateFor(action, playerInput), c.prompts().Version, c.prompts().Source)
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+
+	start := time.Now()
+	reply, err := c.generateSafely(ctx, prompt)
+	var blocked *BlockedError
+	if errors.Is(err, context.Canceled) {
+		log.Println("LLM call canceled by the player.")
+		return "", err
 	}
-
-	// Clues
-	clueItems := []string{}
-	for key, val := range gameState.Clues {
-		clueItems = append(clueItems, fmt.Sprintf("%s: %s", key, val))
+	if errors.As(err, &blocked) {
+		log.Println("LLM outcome: blocked, using the offline description")
+		c.addCall(time.Since(start), true)
+		return offlineReply(playerInput, gameState), nil
 	}
-	if len(clueItems) > 0 {
-		sb.WriteString(fmt.Sprintf("\nKnown Clues: %s", strings.Join(clueItems, "; ")))
+	if err != nil {
+		log.Printf("LLM unavailable, using the offline description: %v", err)
+		c.addCall(time.Since(start), true)
+		return offlineReply(playerInput, gameState), nil
 	}
 
-	// --- Player's Action ---
-	sb.WriteString("\n\n--- Player Action ---")
-	sb.WriteString(fmt.Sprintf("\n%s", playerInput))
+	// Check the reply against the game before the player sees it
+	reply = reviewReply(reply, playerInput, gameState, func(correction string) (string, error) {
+		return c.generateSafely(ctx, prompt+"\n\n"+correction)
+	})
+	c.addCall(time.Since(start), false)
+	c.logUsage()
+	return reply, nil
+}
 
-	// --- Instruction for LLM ---
-	sb.WriteString("\n\n--- Narrator Response ---")
-	sb.WriteString("\nDescribe the result: ") // Prompt LLM to generate the narrative
+// buildPrompt renders the prompt template for the player's action
+func (c *Client) buildPrompt(playerInput string, gameState *game.GameState) (string, Action, error) {
+	c.mu.Lock()
+	recentEvents := slices.Clone(c.recentEvents)
+	c.mu.Unlock()
 
-	return sb.String()
+	action := ActionFor(playerInput)
+	prompt, err := c.prompts().Render(action, playerInput, gameState, recentEvents)
+	return prompt, action, err
 }
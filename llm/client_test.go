@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+
+	"blackoutbargain/game"
+)
+
+// playTo runs commands against a new game
+func playTo(commands ...string) *game.GameState {
+	gs := game.NewGameState()
+	for _, command := range commands {
+		gs.HandleCommand(command)
+	}
+	return gs
+}
+
+func TestBuildPromptHidesUndiscoveredSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    *game.GameState
+		hidden   []string // Secrets the prompt must not mention yet
+		revealed []string // Secrets the prompt may now mention
+	}{
+		{
+			name:   "start",
+			state:  playTo(),
+			hidden: []string{"8675309", "4711", "OVERSTOCK", "683778625"},
+		},
+		{
+			name:   "at the locker without the scanner",
+			state:  playTo("n", "e"),
+			hidden: []string{"8675309", "4711", "OVERSTOCK", "683778625"},
+		},
+		{
+			name:     "holding the scanner",
+			state:    playTo("n", "take dale's handheld scanner", "e"),
+			hidden:   []string{"4711", "OVERSTOCK", "683778625"},
+			revealed: []string{"8675309"},
+		},
+		{
+			name:     "at the safe with the notebook",
+			state:    playTo("n", "take dale's handheld scanner", "e", "use 8675309", "8675309", "w", "w"),
+			hidden:   []string{"4711"},
+			revealed: []string{"8675309", "OVERSTOCK"},
+		},
+		{
+			name:     "at the breaker panel with everything",
+			state:    playTo("n", "take dale's handheld scanner", "e", "use 8675309", "8675309", "w", "w", "take daily inventory printout", "use 4711", "4711", "ne"),
+			revealed: []string{"8675309", "OVERSTOCK", "4711"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, _, err := (&Client{}).buildPrompt("look around", tt.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prompt = strings.ToLower(prompt)
+
+			// The hard-coded list catches a wrong RevealedBy in game.Secrets,
+			// which HiddenSecrets would agree with
+			for _, secret := range append(tt.hidden, tt.state.HiddenSecrets()...) {
+				if strings.Contains(prompt, strings.ToLower(secret)) {
+					t.Errorf("prompt reveals undiscovered secret %q", secret)
+				}
+			}
+			for _, secret := range tt.revealed {
+				if !strings.Contains(prompt, strings.ToLower(secret)) {
+					t.Errorf("prompt is missing discovered secret %q", secret)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildPromptHidesKiller(t *testing.T) {
+	gs := playTo("n", "take dale's handheld scanner")
+	for _, input := range []string{"who did it?", "talk gary", "ask brenda about gary"} {
+		prompt, _, err := (&Client{}).buildPrompt(input, gs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, spoiler := range []string{"killer", "skimming", "syringe", "Gary is the", "Gary killed"} {
+			if strings.Contains(prompt, spoiler) {
+				t.Errorf("%q prompt contains %q before the confession", input, spoiler)
+			}
+		}
+	}
+
+	// Gary's own character notes mustn't hint at his guilt either
+	prompt, _, err := (&Client{}).buildPrompt("talk gary", gs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, block, _ := strings.Cut(prompt, "--- Gary ---")
+	if block == "" {
+		t.Fatal("talk gary prompt has no Gary section")
+	}
+	if strings.Contains(block, "Dale's death") {
+		t.Errorf("Gary's persona mentions Dale's death before the confession:\n%s", block)
+	}
+}
@@ -0,0 +1,213 @@
+package llm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"blackoutbargain/game"
+)
+
+// --- Narrator Reply Filter ---
+
+// ViolationKind says how a narrator reply contradicts the game
+type ViolationKind int
+
+const (
+	ViolationSecret      ViolationKind = iota // Reveals a code the player hasn't found
+	ViolationUnknownItem                      // Hands the player an item that doesn't exist
+	ViolationStateChange                      // Claims something happened that the engine didn't do
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case ViolationSecret:
+		return "secret"
+	case ViolationUnknownItem:
+		return "unknown item"
+	case ViolationStateChange:
+		return "state change"
+	default:
+		return "unknown"
+	}
+}
+
+// Violation is a single problem found in a narrator reply
+type Violation struct {
+	Kind   ViolationKind
+	Detail string
+}
+
+// redaction replaces secrets the narrator let slip
+const redaction = "[smudged]"
+
+// acquisitionPattern finds claims that the player picked something up. Verbs
+// like "take" and "grab" are left out, since "you take a deep breath" is fine.
+// The groups are the verb, the article if any, and what was picked up.
+var acquisitionPattern = regexp.MustCompile(`(?i)\byou (pick up|picked up|pocket|pocketed|stash|stashed|now (?:have|carry|hold))\s+(?:(the|a|an|some) )?([a-z'\- ]{3,40})`)
+
+// stateClaim is a phrase that only makes sense once the engine has set a flag
+type stateClaim struct {
+	pattern *regexp.Regexp
+	flag    string
+	detail  string
+}
+
+// stateClaims are the story events the narrator must leave to the engine.
+// They match outcomes only, so describing an attempt is fine.
+var stateClaims = []stateClaim{
+	{
+		pattern: regexp.MustCompile(`(?i)locker (?:door )?(?:swings|springs|pops|clicks|creaks) open|you (?:open|opened) (?:dale's |the )?locker`),
+		flag:    "locker_opened",
+		detail:  "claims Dale's locker opened",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)safe (?:door )?(?:swings|springs|pops|clicks|creaks) open|you (?:open|opened) the safe`),
+		flag:    "safe_opened",
+		detail:  "claims the safe opened",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)(?:doors?|locks?) (?:unlock|release|click open|slide open)|power (?:returns|comes back)|lights (?:flicker|come) back on|you escape`),
+		flag:    "door_unlocked",
+		detail:  "claims the power or the doors came back",
+	},
+}
+
+// hedgePattern finds words that turn a claim into an attempt, a refusal or a
+// maybe, as in "you try to open the safe" or "until you escape"
+var hedgePattern = regexp.MustCompile(`(?i)n['’]t\b|\b(?:cannot|not|never|no longer|try|tries|tried|trying|attempts?|attempted|until|unless|if|once|before|whether)\b`)
+
+// Validate checks a narrator reply against what the game knows
+func Validate(reply string, gs *game.GameState) []Violation {
+	violations := []Violation{}
+	lower := strings.ToLower(reply)
+
+	for _, secret := range gs.HiddenSecrets() {
+		if strings.Contains(lower, strings.ToLower(secret)) {
+			violations = append(violations, Violation{Kind: ViolationSecret, Detail: fmt.Sprintf("reveals %q", secret)})
+		}
+	}
+
+	for _, match := range acquisitionPattern.FindAllStringSubmatch(reply, -1) {
+		verb, article, phrase := strings.ToLower(match[1]), match[2], strings.TrimSpace(match[3])
+		item, known := matchItem(phrase)
+		switch {
+		case known && !gs.Inventory[item]:
+			violations = append(violations, Violation{Kind: ViolationStateChange, Detail: fmt.Sprintf("claims the player took the %s", item)})
+		case !known && article != "" && !strings.HasPrefix(verb, "now "):
+			// "You now have a bad feeling" isn't about an item
+			violations = append(violations, Violation{Kind: ViolationUnknownItem, Detail: fmt.Sprintf("gives the player %q", phrase)})
+		}
+	}
+
+	for _, claim := range stateClaims {
+		if _, done := gs.Clues[claim.flag]; !done && claims(reply, claim.pattern) {
+			violations = append(violations, Violation{Kind: ViolationStateChange, Detail: claim.detail})
+		}
+	}
+	return violations
+}
+
+// claims reports whether the reply states an outcome outright, ignoring
+// matches hedged earlier in the same sentence
+func claims(reply string, pattern *regexp.Regexp) bool {
+	for _, at := range pattern.FindAllStringIndex(reply, -1) {
+		sentence := reply[:at[0]]
+		if end := strings.LastIndexAny(sentence, ".!?;"); end >= 0 {
+			sentence = sentence[end+1:]
+		}
+		if !hedgePattern.MatchString(sentence) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchItem finds the game item a phrase refers to by the last word of its name
+func matchItem(phrase string) (game.Item, bool) {
+	words := strings.Fields(strings.ToLower(phrase))
+	for _, placement := range game.Placements {
+		name := strings.Fields(strings.ToLower(string(placement.Item)))
+		if slices.Contains(words, name[len(name)-1]) {
+			return placement.Item, true
+		}
+	}
+	return "", false
+}
+
+// Redact hides every undiscovered secret in a reply
+func Redact(reply string, gs *game.GameState) string {
+	for _, secret := range gs.HiddenSecrets() {
+		pattern := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(secret))
+		reply = pattern.ReplaceAllString(reply, redaction)
+	}
+	return reply
+}
+
+// reviewReply checks a reply before the player sees it. A reply that makes up
+// items or events gets one retry with a correction, then falls back to the
+// offline description. Secrets that slip through are redacted.
+func reviewReply(reply, playerInput string, gs *game.GameState, retry func(correction string) (string, error)) string {
+	violations := Validate(reply, gs)
+	logViolations(violations)
+
+	if needsRetry(violations) {
+		corrected, err := retry(correction(violations))
+		if err != nil {
+			log.Printf("Narrator retry failed: %v", err)
+		} else {
+			violations = Validate(corrected, gs)
+			logViolations(violations)
+		}
+		if err != nil || needsRetry(violations) {
+			log.Println("Narrator reply rejected, using the offline description.")
+			return offlineReply(playerInput, gs)
+		}
+		reply = corrected
+	}
+	return Redact(reply, gs)
+}
+
+// needsRetry reports whether any violation can't be fixed by redaction
+func needsRetry(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Kind != ViolationSecret {
+			return true
+		}
+	}
+	return false
+}
+
+// correction tells the model what was wrong with its last reply
+func correction(violations []Violation) string {
+	problems := make([]string, len(violations))
+	for i, v := range violations {
+		problems[i] = v.Detail
+	}
+	return "Your previous reply was rejected because it " + strings.Join(problems, "; ") +
+		". Rewrite it. Only the game decides what the player picks up or unlocks, so describe attempts and surroundings, not outcomes."
+}
+
+// logViolations records each problem found in a reply
+func logViolations(violations []Violation) {
+	for _, v := range violations {
+		log.Printf("Narrator violation (%s): %s", v.Kind, v.Detail)
+	}
+}
+
+// offlineReply is the offline description for a command, with any secret it
+// would give away redacted
+func offlineReply(playerInput string, gs *game.GameState) string {
+	return Redact(gs.DescribeOffline(objectOf(playerInput)), gs)
+}
+
+// objectOf returns what the player's command was aimed at, without the verb
+func objectOf(playerInput string) string {
+	parts := strings.Fields(playerInput)
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.Join(parts[1:], " ")
+}
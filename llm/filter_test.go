@@ -0,0 +1,202 @@
+package llm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		reply    string
+		expected []ViolationKind
+	}{
+		{
+			name:     "plain description",
+			reply:    "Rain hammers the skylights. You take a deep breath.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "leaks the locker code",
+			reply:    "Scratched into the desk: 8675309.",
+			expected: []ViolationKind{ViolationSecret},
+		},
+		{
+			name:     "repeats a code the player found",
+			commands: []string{"n", "take dale's handheld scanner"},
+			reply:    "The scanner still reads 8675309.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "leaks the breaker code in any case",
+			reply:    "Someone wrote Overstock on the wall.",
+			expected: []ViolationKind{ViolationSecret},
+		},
+		{
+			name:     "invents an item",
+			reply:    "You pocket a rusty crowbar.",
+			expected: []ViolationKind{ViolationUnknownItem},
+		},
+		{
+			name:     "hands over a real item early",
+			reply:    "You pick up the scanner from the floor.",
+			expected: []ViolationKind{ViolationStateChange},
+		},
+		{
+			name:     "mentions an item the player holds",
+			commands: []string{"n", "take dale's handheld scanner"},
+			reply:    "You now hold the scanner tightly.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "opens the locker",
+			reply:    "With a jiggle, the locker swings open.",
+			expected: []ViolationKind{ViolationStateChange},
+		},
+		{
+			name:     "holds a breath",
+			reply:    "You now hold your breath as footsteps approach.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "has a feeling",
+			reply:    "You now have a bad feeling about Gary.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "can't open the locker",
+			reply:    "You can't open Dale's locker without the combination.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "tries the safe",
+			reply:    "You try to open the safe, but the dial won't budge.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "opens the safe",
+			reply:    "You open the safe and reach inside.",
+			expected: []ViolationKind{ViolationStateChange},
+		},
+		{
+			name:     "escape as a hope",
+			reply:    "Brenda says you should stick together until you escape together.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "doors won't unlock",
+			reply:    "The doors won't unlock without power.",
+			expected: []ViolationKind{},
+		},
+		{
+			name:     "restores the power",
+			reply:    "The lights flicker back on and the doors unlock.",
+			expected: []ViolationKind{ViolationStateChange},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := Validate(tt.reply, playTo(tt.commands...))
+			kinds := []ViolationKind{}
+			for _, v := range violations {
+				kinds = append(kinds, v.Kind)
+			}
+			if len(kinds) != len(tt.expected) {
+				t.Fatalf("Validate() = %v, want kinds %v", violations, tt.expected)
+			}
+			for i := range kinds {
+				if kinds[i] != tt.expected[i] {
+					t.Errorf("violation %d kind = %v, want %v", i, kinds[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	got := Redact("Try 8675309, or maybe overstock.", playTo())
+	if want := "Try [smudged], or maybe [smudged]."; got != want {
+		t.Errorf("Redact() = %q, want %q", got, want)
+	}
+}
+
+func TestReviewReply(t *testing.T) {
+	tests := []struct {
+		name        string
+		reply       string
+		retryReply  string
+		retryErr    error
+		expected    string
+		expectRetry bool
+	}{
+		{
+			name:     "clean reply passes through",
+			reply:    "Shelves loom in the dark.",
+			expected: "Shelves loom in the dark.",
+		},
+		{
+			name:     "secret is redacted without a retry",
+			reply:    "A note reads 4711.",
+			expected: "A note reads [smudged].",
+		},
+		{
+			name:        "invented event is regenerated",
+			reply:       "The safe clicks open.",
+			retryReply:  "You spin the dial, but the safe stays shut.",
+			expected:    "You spin the dial, but the safe stays shut.",
+			expectRetry: true,
+		},
+		{
+			name:        "second bad reply falls back",
+			reply:       "You pocket a flashlight.",
+			retryReply:  "You pocket a lantern instead.",
+			expected:    playTo().DescribeOffline("around"),
+			expectRetry: true,
+		},
+		{
+			name:        "failed retry falls back",
+			reply:       "The power comes back.",
+			retryErr:    errors.New("quota exceeded"),
+			expected:    playTo().DescribeOffline("around"),
+			expectRetry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retried := false
+			retry := func(correction string) (string, error) {
+				retried = true
+				if !strings.Contains(correction, "rejected") {
+					t.Errorf("correction %q doesn't explain the rejection", correction)
+				}
+				return tt.retryReply, tt.retryErr
+			}
+
+			got := reviewReply(tt.reply, "look around", playTo(), retry)
+			if got != tt.expected {
+				t.Errorf("reviewReply() = %q, want %q", got, tt.expected)
+			}
+			if retried != tt.expectRetry {
+				t.Errorf("retried = %v, want %v", retried, tt.expectRetry)
+			}
+		})
+	}
+}
+
+func TestOfflineReplyKeepsSecrets(t *testing.T) {
+	// The card names the breaker code before the notebook explains it
+	gs := playTo("n", "w", "take laminated emergency procedure card")
+	retry := func(string) (string, error) { return "", errors.New("quota exceeded") }
+
+	got := reviewReply("The safe clicks open.", "examine laminated emergency procedure card", gs, retry)
+	if strings.Contains(strings.ToLower(got), "overstock") {
+		t.Errorf("offline fallback %q reveals the breaker code", got)
+	}
+	if !strings.Contains(got, redaction) {
+		t.Errorf("offline fallback %q, want the card text with the code smudged", got)
+	}
+}
@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// --- Text Generation ---
+
+// Generator turns a prompt into text. GeminiGenerator talks to the real
+// service; tests swap in their own.
+type Generator interface {
+	Generate(ctx context.Context, prompt string) (Reply, error)
+}
+
+// Reply is what a generator sends back
+type Reply struct {
+	Text  string
+	Usage Usage // Tokens billed; zero when nothing was, as for a cache hit
+}
+
+// GeminiGenerator generates text with a Gemini model
+type GeminiGenerator struct {
+	Model *genai.GenerativeModel
+}
+
+// Generate sends a prompt to Gemini and returns the cleaned-up reply
+func (g *GeminiGenerator) Generate(ctx context.Context, prompt string) (Reply, error) {
+	resp, err := g.Model.GenerateContent(ctx, genai.Text(prompt))
+	var blocked *genai.BlockedError
+	if errors.As(err, &blocked) {
+		return Reply{}, blockedFrom(blocked)
+	}
+	if err != nil {
+		return Reply{}, err
+	}
+	return replyFrom(resp), nil
+}
+
+// emptyReply stands in when the model has nothing to say
+const emptyReply = "The situation doesn't seem to change."
+
+// replyFrom reads the text, token counts and finish reason of a response
+func replyFrom(resp *genai.GenerateContentResponse) Reply {
+	reply := Reply{Text: emptyReply}
+	if resp.UsageMetadata != nil {
+		reply.Usage = Usage{
+			PromptTokens:   int(resp.UsageMetadata.PromptTokenCount),
+			ResponseTokens: int(resp.UsageMetadata.CandidatesTokenCount),
+		}
+	}
+
+	// Handle cases where the LLM response is empty or malformed
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+		log.Printf("LLM outcome: empty reply: %+v", resp)
+		return reply
+	}
+
+	candidate := resp.Candidates[0]
+	generatedText := fmt.Sprintf("%v", candidate.Content.Parts[0])
+
+	switch candidate.FinishReason {
+	case genai.FinishReasonStop, genai.FinishReasonUnspecified:
+		log.Println("LLM outcome: ok")
+	case genai.FinishReasonMaxTokens:
+		log.Println("LLM outcome: cut off at the token limit, trimmed to the last full sentence")
+		generatedText = trimToSentence(generatedText)
+	default:
+		log.Printf("LLM outcome: stopped early (%v)", candidate.FinishReason)
+	}
+	reply.Text = generatedText
+	return reply
+}
+
+// trimToSentence drops a trailing half sentence, if there is a full one to keep
+func trimToSentence(text string) string {
+	end := strings.LastIndexAny(text, ".!?")
+	if end <= 0 {
+		return text
+	}
+	// Keep the quote that closes a line of dialogue
+	if end+1 < len(text) && (text[end+1] == '"' || text[end+1] == '\'') {
+		end++
+	}
+	return text[:end+1]
+}
@@ -0,0 +1,191 @@
+package llm
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"blackoutbargain/game"
+)
+
+// --- Prompt Templates ---
+
+//go:embed prompts/*.tmpl
+var embeddedPrompts embed.FS
+
+// Action picks the prompt template for a player command
+type Action string
+
+const (
+	ActionExamine Action = "examine"
+	ActionTalk    Action = "talk"
+	ActionUse     Action = "use"
+	ActionUnknown Action = "unknown"
+)
+
+// Actions lists every action, each of which has a template
+var Actions = []Action{ActionExamine, ActionTalk, ActionUse, ActionUnknown}
+
+// actionVerbs maps the first word of a command to its action
+var actionVerbs = map[string]Action{
+	"examine": ActionExamine, "look": ActionExamine, "inspect": ActionExamine, "search": ActionExamine, "read": ActionExamine, "check": ActionExamine,
+	"talk": ActionTalk, "ask": ActionTalk, "speak": ActionTalk, "question": ActionTalk, "tell": ActionTalk,
+	"use": ActionUse, "open": ActionUse, "push": ActionUse, "pull": ActionUse, "turn": ActionUse, "try": ActionUse,
+}
+
+// ActionFor returns the action a player command falls under
+func ActionFor(playerInput string) Action {
+	parts := strings.Fields(strings.ToLower(playerInput))
+	if len(parts) == 0 {
+		return ActionUnknown
+	}
+	if action, ok := actionVerbs[parts[0]]; ok {
+		return action
+	}
+	return ActionUnknown
+}
+
+// Prompts renders narrator prompts from a set of templates
+type Prompts struct {
+	Version string // From the "version" template, stamped into the logs
+	Source  string // "embedded" or the override directory
+
+	tmpl *template.Template
+}
+
+// promptData is what the templates can see
+type promptData struct {
+	Location            string
+	LocationDescription string
+	Notes               string
+	Exits               string
+	Items               string
+	Inventory           string
+	Facts               []string
+	RecentEvents        []string
+	PlayerInput         string
+	Persona             *personaData // Set when the player talks to a character
+}
+
+// personaData is a character as the persona template sees it
+type personaData struct {
+	Name       string
+	Role       string
+	Voice      string
+	Present    bool
+	Suspicion  string
+	Knows      []string
+	Hides      []string
+	CoverStory string
+}
+
+// PersonaFor returns the character a talk command is aimed at, as in
+// "talk brenda" or "ask gary about dale"
+func PersonaFor(playerInput string) (game.Persona, bool) {
+	if ActionFor(playerInput) != ActionTalk {
+		return game.Persona{}, false
+	}
+	for _, word := range strings.Fields(playerInput)[1:] {
+		if persona, ok := game.FindPersona(strings.Trim(word, ".,?!'")); ok {
+			return persona, true
+		}
+	}
+	return game.Persona{}, false
+}
+
+// templateFor picks the template for a command: the persona template when
+// the player talks to a character, otherwise the action's own
+func templateFor(action Action, playerInput string) string {
+	if _, ok := PersonaFor(playerInput); ok {
+		return "persona"
+	}
+	return string(action)
+}
+
+var promptFuncs = template.FuncMap{"join": strings.Join}
+
+// DefaultPrompts returns the templates built into the binary. They are parsed
+// once and shared, so treat them as read-only.
+var DefaultPrompts = sync.OnceValue(func() *Prompts {
+	prompts, err := LoadPrompts("")
+	if err != nil {
+		panic(fmt.Sprintf("embedded prompt templates: %v", err))
+	}
+	return prompts
+})
+
+// prompts returns the client's templates, or the built-in ones if it has none
+func (c *Client) prompts() *Prompts {
+	if c.Prompts == nil {
+		return DefaultPrompts()
+	}
+	return c.Prompts
+}
+
+// LoadPrompts parses the embedded templates, then any *.tmpl files in dir on
+// top of them. Overrides work per template name, not per file: a file
+// replaces the embedded template of the same name and any {{define}} blocks
+// it redefines, and every other block, such as "premise" in narrator.tmpl,
+// is kept. An override directory only needs the templates it changes.
+func LoadPrompts(dir string) (*Prompts, error) {
+	tmpl, err := template.New("prompts").Funcs(promptFuncs).ParseFS(embeddedPrompts, "prompts/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	source := "embedded"
+	if dir != "" {
+		if tmpl, err = tmpl.ParseFS(os.DirFS(dir), "*.tmpl"); err != nil {
+			return nil, fmt.Errorf("loading prompt overrides from %s: %w", dir, err)
+		}
+		source = dir
+	}
+
+	for _, name := range append(slices.Clone(Actions), "persona") {
+		if tmpl.Lookup(string(name)+".tmpl") == nil {
+			return nil, fmt.Errorf("missing prompt template %s.tmpl", name)
+		}
+	}
+	var version strings.Builder
+	if err := tmpl.ExecuteTemplate(&version, "version", nil); err != nil {
+		return nil, fmt.Errorf("prompt templates need a version: %w", err)
+	}
+	return &Prompts{Version: strings.TrimSpace(version.String()), Source: source, tmpl: tmpl}, nil
+}
+
+// Render builds the prompt for an action
+func (p *Prompts) Render(action Action, playerInput string, gameState *game.GameState, recentEvents []string) (string, error) {
+	data := promptData{
+		Location:            gameState.GetLocationName(),
+		LocationDescription: gameState.GetLocationDescription(),
+		Notes:               gameState.LocationNotes(),
+		Exits:               gameState.GetExitsDescription(),
+		Items:               gameState.GetVisibleItems(),
+		Inventory:           gameState.GetInventoryDescription(),
+		Facts:               gameState.KnownFacts(),
+		RecentEvents:        recentEvents,
+		PlayerInput:         playerInput,
+	}
+	if persona, ok := PersonaFor(playerInput); ok {
+		data.Persona = &personaData{
+			Name:       persona.Name,
+			Role:       persona.Role,
+			Voice:      persona.Voice,
+			Present:    persona.Location == gameState.Location,
+			Suspicion:  gameState.Suspicion(persona),
+			Knows:      gameState.PersonaFacts(persona),
+			Hides:      gameState.PersonaHides(persona),
+			CoverStory: persona.CoverStory,
+		}
+	}
+
+	name := templateFor(action, playerInput)
+	var sb strings.Builder
+	if err := p.tmpl.ExecuteTemplate(&sb, name+".tmpl", data); err != nil {
+		return "", fmt.Errorf("rendering %s prompt: %w", name, err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,208 @@
+package llm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blackoutbargain/game"
+)
+
+func TestActionFor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Action
+	}{
+		{"examine the monitors", ActionExamine},
+		{"Look around", ActionExamine},
+		{"talk brenda", ActionTalk},
+		{"ask gary about dale", ActionTalk},
+		{"use the register", ActionUse},
+		{"open the freezer", ActionUse},
+		{"dance", ActionUnknown},
+		{"", ActionUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ActionFor(tt.input); got != tt.expected {
+			t.Errorf("ActionFor(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderEveryTemplate(t *testing.T) {
+	states := []struct {
+		name  string
+		state *game.GameState
+	}{
+		{"start", playTo()},
+		{"holding the scanner", playTo("n", "take dale's handheld scanner")},
+		{"in the office", playTo("n", "take dale's handheld scanner", "e", "use 8675309", "8675309", "w", "w")},
+	}
+	prompts := DefaultPrompts()
+
+	for _, action := range Actions {
+		for _, st := range states {
+			t.Run(string(action)+"/"+st.name, func(t *testing.T) {
+				prompt, err := prompts.Render(action, "poke around", st.state, []string{"Took the small notebook"})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, want := range []string{
+					"You are the narrator for 'Blackout Bargain'",
+					"Location: " + st.state.GetLocationName(),
+					st.state.GetInventoryDescription(),
+					"--- What the Player Knows ---",
+					"Recent Events: Took the small notebook",
+					"--- Player Action ---\npoke around",
+				} {
+					if !strings.Contains(prompt, want) {
+						t.Errorf("prompt is missing %q:\n%s", want, prompt)
+					}
+				}
+				for _, fact := range st.state.KnownFacts() {
+					if !strings.Contains(prompt, "- "+fact) {
+						t.Errorf("prompt is missing known fact %q", fact)
+					}
+				}
+				if strings.Contains(prompt, "<no value>") {
+					t.Errorf("prompt has an unfilled field:\n%s", prompt)
+				}
+			})
+		}
+	}
+}
+
+func TestLoadPromptsOverride(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "version"}}test-7{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "narrator.tmpl"), []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "talk.tmpl"), []byte("Say hi to {{.PlayerInput}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prompts, err := LoadPrompts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompts.Version != "test-7" || prompts.Source != dir {
+		t.Errorf("Version, Source = %q, %q; want %q, %q", prompts.Version, prompts.Source, "test-7", dir)
+	}
+
+	talk, err := prompts.Render(ActionTalk, "brenda", playTo(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if talk != "Say hi to brenda" {
+		t.Errorf("overridden talk prompt = %q", talk)
+	}
+	// Templates that aren't overridden still come from the binary
+	examine, err := prompts.Render(ActionExamine, "look", playTo(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(examine, "Blackout Bargain") {
+		t.Errorf("embedded examine prompt missing after override:\n%s", examine)
+	}
+}
+
+func TestLoadPromptsBadOverride(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "use.tmpl"), []byte("{{.Nope"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPrompts(dir); err == nil {
+		t.Error("LoadPrompts() accepted a broken template")
+	}
+}
+
+func TestPersonaFor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"talk brenda", "Brenda"},
+		{"ask gary about dale", "Gary"},
+		{"ask brenda about gary", "Brenda"},
+		{"talk to Gary?", "Gary"},
+		{"talk dale", ""},
+		{"examine gary", ""},
+	}
+
+	for _, tt := range tests {
+		persona, _ := PersonaFor(tt.input)
+		if persona.Name != tt.expected {
+			t.Errorf("PersonaFor(%q) = %q, want %q", tt.input, persona.Name, tt.expected)
+		}
+	}
+}
+
+func TestRenderPersona(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		state    *game.GameState
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "gary sticks to his story",
+			input:    "ask gary about dale",
+			state:    playTo(),
+			contains: []string{"talking to Gary, the store manager", "Cover story", "break room", "Feeling toward the player: Relaxed"},
+			excludes: []string{"isn't here"},
+		},
+		{
+			name:     "gary grows wary",
+			input:    "talk gary",
+			state:    playTo("n", "take dale's handheld scanner", "s"),
+			contains: []string{"Feeling toward the player: Wary"},
+		},
+		{
+			name:     "brenda keeps quiet about the stock counts",
+			input:    "talk brenda",
+			state:    playTo(),
+			contains: []string{"talking to Brenda", "Dale was her friend"},
+			excludes: []string{"stock counts", "Cover story"},
+		},
+		{
+			name:     "brenda opens up after dale's notes",
+			input:    "talk brenda",
+			state:    playTo("n", "take dale's handheld scanner", "e", "use 8675309", "8675309", "w", "s"),
+			contains: []string{"stock counts"},
+		},
+		{
+			name:     "brenda isn't at the locker",
+			input:    "talk brenda",
+			state:    playTo("n", "e"),
+			contains: []string{"Brenda isn't here"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, err := DefaultPrompts().Render(ActionFor(tt.input), tt.input, tt.state, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(prompt, want) {
+					t.Errorf("prompt is missing %q:\n%s", want, prompt)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(prompt, unwanted) {
+					t.Errorf("prompt contains %q:\n%s", unwanted, prompt)
+				}
+			}
+			for _, secret := range tt.state.HiddenSecrets() {
+				if strings.Contains(prompt, secret) {
+					t.Errorf("persona prompt reveals undiscovered secret %q", secret)
+				}
+			}
+		})
+	}
+}
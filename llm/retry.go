@@ -0,0 +1,95 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// --- Timeouts and Retries ---
+
+// Defaults for a Client that doesn't set its own
+const (
+	DefaultTimeout     = 20 * time.Second       // Whole call, retries included
+	defaultMaxAttempts = 3                      // First try plus retries
+	defaultRetryDelay  = 500 * time.Millisecond // Doubled after each retry
+)
+
+func (c *Client) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (c *Client) maxAttempts() int {
+	if c.MaxAttempts > 0 {
+		return c.MaxAttempts
+	}
+	return defaultMaxAttempts
+}
+
+func (c *Client) retryDelay() time.Duration {
+	if c.RetryDelay > 0 {
+		return c.RetryDelay
+	}
+	return defaultRetryDelay
+}
+
+// generate calls the generator and counts the tokens it was billed. Transient
+// failures are retried with jittered exponential backoff until the attempts
+// or the context run out.
+func (c *Client) generate(ctx context.Context, prompt string) (string, error) {
+	delay := c.retryDelay()
+	for attempt := 1; ; attempt++ {
+		reply, err := c.Generator.Generate(ctx, prompt)
+		if err == nil {
+			c.addTokens(reply.Usage)
+			return reply.Text, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if attempt >= c.maxAttempts() || !isTransient(err) {
+			log.Printf("LLM API call error: %v", err)
+			return "", fmt.Errorf("API request failed: %w", err)
+		}
+
+		wait := jitter(delay)
+		log.Printf("LLM API call failed (attempt %d of %d), retrying in %s: %v", attempt, c.maxAttempts(), wait.Round(time.Millisecond), err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// jitter spreads a delay over half to one and a half times its length, so
+// clients that failed together don't retry together
+func jitter(delay time.Duration) time.Duration {
+	return delay/2 + rand.N(delay)
+}
+
+// isTransient reports whether an error is worth retrying: rate limits and
+// server-side failures, over either gRPC or REST
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.ResourceExhausted, codes.Unavailable, codes.Internal, codes.Aborted:
+		return true
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError
+	}
+	return false
+}
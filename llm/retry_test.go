@@ -0,0 +1,133 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeGenerator fails with each error in turn, then replies
+type fakeGenerator struct {
+	errs    []error
+	reply   string
+	usage   Usage // Billed for each reply
+	calls   int
+	prompts []string // Every prompt received
+	block   bool     // Wait for the context instead of replying
+}
+
+func (f *fakeGenerator) Generate(ctx context.Context, prompt string) (Reply, error) {
+	f.calls++
+	f.prompts = append(f.prompts, prompt)
+	if f.block {
+		<-ctx.Done()
+		return Reply{}, ctx.Err()
+	}
+	if f.calls <= len(f.errs) {
+		return Reply{}, f.errs[f.calls-1]
+	}
+	return Reply{Text: f.reply, Usage: f.usage}, nil
+}
+
+// newTestClient returns an enabled client with fast retries
+func newTestClient(generator Generator) *Client {
+	return &Client{Enabled: true, Generator: generator, RetryDelay: time.Millisecond, Timeout: time.Second}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"rate limited over gRPC", status.Error(codes.ResourceExhausted, "quota"), true},
+		{"unavailable over gRPC", status.Error(codes.Unavailable, "try later"), true},
+		{"bad request over gRPC", status.Error(codes.InvalidArgument, "bad prompt"), false},
+		{"rate limited over REST", &googleapi.Error{Code: http.StatusTooManyRequests}, true},
+		{"server error over REST", &googleapi.Error{Code: http.StatusBadGateway}, true},
+		{"forbidden over REST", &googleapi.Error{Code: http.StatusForbidden}, false},
+		{"plain error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isTransient(tt.err); got != tt.expected {
+			t.Errorf("%s: isTransient() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestGenerateResponseRetries(t *testing.T) {
+	rateLimited := status.Error(codes.ResourceExhausted, "quota")
+	offline := playTo().DescribeOffline("around")
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expected      string
+		expectedCalls int
+	}{
+		{name: "first try", expected: "Shelves loom.", expectedCalls: 1},
+		{name: "rate limited once", errs: []error{rateLimited}, expected: "Shelves loom.", expectedCalls: 2},
+		{name: "rate limited throughout", errs: []error{rateLimited, rateLimited, rateLimited}, expected: offline, expectedCalls: 3},
+		{name: "permanent error", errs: []error{status.Error(codes.PermissionDenied, "bad key")}, expected: offline, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &fakeGenerator{errs: tt.errs, reply: "Shelves loom."}
+			got, err := newTestClient(generator).GenerateResponse(context.Background(), "look around", playTo())
+			if err != nil {
+				t.Fatalf("GenerateResponse() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("GenerateResponse() = %q, want %q", got, tt.expected)
+			}
+			if generator.calls != tt.expectedCalls {
+				t.Errorf("generator called %d times, want %d", generator.calls, tt.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestGenerateResponseTimeout(t *testing.T) {
+	client := newTestClient(&fakeGenerator{block: true})
+	client.Timeout = 10 * time.Millisecond
+
+	got, err := client.GenerateResponse(context.Background(), "examine dale's handheld scanner", playTo())
+	if err != nil {
+		t.Fatalf("GenerateResponse() error = %v", err)
+	}
+	if want := playTo().DescribeOffline("dale's handheld scanner"); got != want {
+		t.Errorf("GenerateResponse() after a timeout = %q, want the offline description %q", got, want)
+	}
+}
+
+func TestGenerateResponseCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	got, err := newTestClient(&fakeGenerator{block: true}).GenerateResponse(ctx, "look around", playTo())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateResponse() error = %v, want context.Canceled", err)
+	}
+	if got != "" {
+		t.Errorf("GenerateResponse() = %q after cancel, want nothing", got)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for range 100 {
+		if got := jitter(time.Second); got < 500*time.Millisecond || got >= 1500*time.Millisecond {
+			t.Fatalf("jitter(1s) = %s, want within [500ms, 1.5s)", got)
+		}
+	}
+}
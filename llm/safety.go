@@ -0,0 +1,136 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// --- Safety Blocks ---
+
+// safetyCategories names the harm categories Gemini filters, for flags and logs
+var safetyCategories = map[string]genai.HarmCategory{
+	"harassment": genai.HarmCategoryHarassment,
+	"hate":       genai.HarmCategoryHateSpeech,
+	"sexual":     genai.HarmCategorySexuallyExplicit,
+	"dangerous":  genai.HarmCategoryDangerousContent,
+}
+
+// safetyLevels names the block thresholds, from most to least blocking
+var safetyLevels = map[string]genai.HarmBlockThreshold{
+	"low":    genai.HarmBlockLowAndAbove,
+	"medium": genai.HarmBlockMediumAndAbove,
+	"high":   genai.HarmBlockOnlyHigh,
+	"none":   genai.HarmBlockNone,
+}
+
+// DefaultSafetyThresholds suit a murder mystery: a dead body and a killer
+// are the whole premise, so violence-adjacent categories only block on high.
+var DefaultSafetyThresholds = map[genai.HarmCategory]genai.HarmBlockThreshold{
+	genai.HarmCategoryHarassment:       genai.HarmBlockOnlyHigh,
+	genai.HarmCategoryHateSpeech:       genai.HarmBlockMediumAndAbove,
+	genai.HarmCategorySexuallyExplicit: genai.HarmBlockMediumAndAbove,
+	genai.HarmCategoryDangerousContent: genai.HarmBlockOnlyHigh,
+}
+
+// ParseSafetyThresholds reads thresholds like "dangerous=none,harassment=medium"
+// on top of the defaults
+func ParseSafetyThresholds(spec string) (map[genai.HarmCategory]genai.HarmBlockThreshold, error) {
+	thresholds := maps.Clone(DefaultSafetyThresholds)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, level, ok := strings.Cut(part, "=")
+		category, knownCategory := safetyCategories[strings.TrimSpace(name)]
+		threshold, knownLevel := safetyLevels[strings.TrimSpace(level)]
+		if !ok || !knownCategory || !knownLevel {
+			return nil, fmt.Errorf("bad safety threshold %q: want category=level with categories %s and levels low, medium, high, none",
+				part, strings.Join(slices.Sorted(maps.Keys(safetyCategories)), ", "))
+		}
+		thresholds[category] = threshold
+	}
+	return thresholds, nil
+}
+
+// SetSafetyThresholds changes what the model blocks, per category
+func (c *Client) SetSafetyThresholds(thresholds map[genai.HarmCategory]genai.HarmBlockThreshold) {
+	if c.GeminiModel == nil {
+		return
+	}
+	c.GeminiModel.SafetySettings = nil
+	for _, category := range slices.Sorted(maps.Keys(thresholds)) {
+		c.GeminiModel.SafetySettings = append(c.GeminiModel.SafetySettings,
+			&genai.SafetySetting{Category: category, Threshold: thresholds[category]})
+	}
+}
+
+// BlockedError reports a reply the model refused to give
+type BlockedError struct {
+	Reason  string   // What was blocked and why, e.g. "prompt: BlockReasonSafety"
+	Safety  bool     // Blocked by a safety filter, so a softer prompt may get through
+	Ratings []string // Categories that were flagged, with their probability
+}
+
+func (e *BlockedError) Error() string {
+	if len(e.Ratings) == 0 {
+		return "blocked: " + e.Reason
+	}
+	return fmt.Sprintf("blocked: %s (%s)", e.Reason, strings.Join(e.Ratings, ", "))
+}
+
+// blockedFrom converts the Gemini client's block error
+func blockedFrom(err *genai.BlockedError) *BlockedError {
+	blocked := &BlockedError{}
+	var ratings []*genai.SafetyRating
+	if err.PromptFeedback != nil {
+		blocked.Reason = fmt.Sprintf("prompt: %v", err.PromptFeedback.BlockReason)
+		blocked.Safety = err.PromptFeedback.BlockReason == genai.BlockReasonSafety
+		ratings = err.PromptFeedback.SafetyRatings
+	}
+	if err.Candidate != nil {
+		blocked.Reason = fmt.Sprintf("reply: %v", err.Candidate.FinishReason)
+		blocked.Safety = err.Candidate.FinishReason == genai.FinishReasonSafety
+		ratings = err.Candidate.SafetyRatings
+	}
+	for _, rating := range ratings {
+		if rating.Blocked || rating.Probability >= genai.HarmProbabilityMedium {
+			blocked.Ratings = append(blocked.Ratings, fmt.Sprintf("%v: %v", rating.Category, rating.Probability))
+		}
+	}
+	return blocked
+}
+
+// softenInstruction is added to a prompt that tripped a safety filter
+const softenInstruction = "Keep this reply suitable for a general audience. Refer to Dale's death and any violence indirectly, without describing injuries or weapons."
+
+// generateSafely handles safety blocks: a prompt that trips a filter is tried
+// once more with a softer instruction. Other blocks, and a second safety
+// block, are returned for the caller to fall back on.
+func (c *Client) generateSafely(ctx context.Context, prompt string) (string, error) {
+	reply, err := c.generate(ctx, prompt)
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) {
+		return reply, err
+	}
+	if !blocked.Safety {
+		log.Printf("LLM outcome: %v, not retrying", blocked)
+		return "", err
+	}
+
+	log.Printf("LLM outcome: safety %v, retrying with a softened prompt", blocked)
+	reply, err = c.generate(ctx, prompt+"\n\n"+softenInstruction)
+	if errors.As(err, &blocked) {
+		log.Printf("LLM outcome: softened prompt still %v", blocked)
+	} else if err == nil {
+		log.Println("LLM outcome: softened prompt answered")
+	}
+	return reply, err
+}
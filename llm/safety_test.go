@@ -0,0 +1,170 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestParseSafetyThresholds(t *testing.T) {
+	tests := []struct {
+		spec      string
+		category  genai.HarmCategory
+		expected  genai.HarmBlockThreshold
+		expectErr bool
+	}{
+		{spec: "", category: genai.HarmCategoryDangerousContent, expected: DefaultSafetyThresholds[genai.HarmCategoryDangerousContent]},
+		{spec: "dangerous=none", category: genai.HarmCategoryDangerousContent, expected: genai.HarmBlockNone},
+		{spec: "harassment=low, hate=high", category: genai.HarmCategoryHateSpeech, expected: genai.HarmBlockOnlyHigh},
+		{spec: "violence=none", expectErr: true},
+		{spec: "dangerous=some", expectErr: true},
+		{spec: "dangerous", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		thresholds, err := ParseSafetyThresholds(tt.spec)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("ParseSafetyThresholds(%q) accepted a bad spec", tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSafetyThresholds(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got := thresholds[tt.category]; got != tt.expected {
+			t.Errorf("ParseSafetyThresholds(%q)[%v] = %v, want %v", tt.spec, tt.category, got, tt.expected)
+		}
+	}
+}
+
+func TestSetSafetyThresholds(t *testing.T) {
+	client := &Client{GeminiModel: &genai.GenerativeModel{}}
+	client.SetSafetyThresholds(map[genai.HarmCategory]genai.HarmBlockThreshold{
+		genai.HarmCategoryDangerousContent: genai.HarmBlockNone,
+		genai.HarmCategoryHarassment:       genai.HarmBlockLowAndAbove,
+	})
+
+	settings := client.GeminiModel.SafetySettings
+	if len(settings) != 2 {
+		t.Fatalf("got %d safety settings, want 2", len(settings))
+	}
+	for _, setting := range settings {
+		if setting.Category == genai.HarmCategoryDangerousContent && setting.Threshold != genai.HarmBlockNone {
+			t.Errorf("dangerous content threshold = %v, want none", setting.Threshold)
+		}
+	}
+}
+
+func TestBlockedFrom(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          *genai.BlockedError
+		expectSafety bool
+		ratings      int
+	}{
+		{
+			name: "prompt blocked for safety",
+			err: &genai.BlockedError{PromptFeedback: &genai.PromptFeedback{
+				BlockReason: genai.BlockReasonSafety,
+				SafetyRatings: []*genai.SafetyRating{
+					{Category: genai.HarmCategoryDangerousContent, Probability: genai.HarmProbabilityHigh, Blocked: true},
+					{Category: genai.HarmCategoryHateSpeech, Probability: genai.HarmProbabilityNegligible},
+				},
+			}},
+			expectSafety: true,
+			ratings:      1,
+		},
+		{
+			name:         "reply blocked for safety",
+			err:          &genai.BlockedError{Candidate: &genai.Candidate{FinishReason: genai.FinishReasonSafety}},
+			expectSafety: true,
+		},
+		{
+			name: "reply blocked for recitation",
+			err:  &genai.BlockedError{Candidate: &genai.Candidate{FinishReason: genai.FinishReasonRecitation}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked := blockedFrom(tt.err)
+			if blocked.Safety != tt.expectSafety {
+				t.Errorf("Safety = %v, want %v", blocked.Safety, tt.expectSafety)
+			}
+			if len(blocked.Ratings) != tt.ratings {
+				t.Errorf("Ratings = %v, want %d", blocked.Ratings, tt.ratings)
+			}
+		})
+	}
+}
+
+func TestReplyFrom(t *testing.T) {
+	response := func(text string, reason genai.FinishReason) *genai.GenerateContentResponse {
+		return &genai.GenerateContentResponse{
+			Candidates:    []*genai.Candidate{{Content: &genai.Content{Parts: []genai.Part{genai.Text(text)}}, FinishReason: reason}},
+			UsageMetadata: &genai.UsageMetadata{PromptTokenCount: 120, CandidatesTokenCount: 30},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		resp     *genai.GenerateContentResponse
+		expected string
+	}{
+		{"finished", response("Rain hammers the skylights.", genai.FinishReasonStop), "Rain hammers the skylights."},
+		{"cut off", response("Rain hammers the skylights. Somewhere a shel", genai.FinishReasonMaxTokens), "Rain hammers the skylights."},
+		{"cut off after dialogue", response(`"Go away." Gary turns to`, genai.FinishReasonMaxTokens), `"Go away."`},
+		{"no candidates", &genai.GenerateContentResponse{}, emptyReply},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replyFrom(tt.resp).Text; got != tt.expected {
+				t.Errorf("replyFrom() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if usage := replyFrom(response("Hi.", genai.FinishReasonStop)).Usage; usage != (Usage{PromptTokens: 120, ResponseTokens: 30}) {
+		t.Errorf("replyFrom() usage = %+v, want 120 prompt and 30 response tokens", usage)
+	}
+}
+
+func TestGenerateResponseSafetyBlocks(t *testing.T) {
+	safetyBlock := &BlockedError{Reason: "reply: FinishReasonSafety", Safety: true}
+	offline := playTo().DescribeOffline("around")
+
+	tests := []struct {
+		name          string
+		errs          []error
+		expected      string
+		expectedCalls int
+	}{
+		{name: "softened prompt gets through", errs: []error{safetyBlock}, expected: "Shelves loom.", expectedCalls: 2},
+		{name: "blocked twice", errs: []error{safetyBlock, safetyBlock}, expected: offline, expectedCalls: 2},
+		{name: "recitation isn't retried", errs: []error{&BlockedError{Reason: "reply: FinishReasonRecitation"}}, expected: offline, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &fakeGenerator{errs: tt.errs, reply: "Shelves loom."}
+			got, err := newTestClient(generator).GenerateResponse(context.Background(), "look around", playTo())
+			if err != nil {
+				t.Fatalf("GenerateResponse() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("GenerateResponse() = %q, want %q", got, tt.expected)
+			}
+			if generator.calls != tt.expectedCalls {
+				t.Errorf("generator called %d times, want %d", generator.calls, tt.expectedCalls)
+			}
+			if generator.calls > 1 && !strings.Contains(generator.prompts[1], softenInstruction) {
+				t.Error("retry after a safety block didn't soften the prompt")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"log"
+	"time"
+)
+
+// --- Usage and Cost ---
+
+// Usage counts the tokens billed for a call
+type Usage struct {
+	PromptTokens   int
+	ResponseTokens int
+}
+
+// Total returns every token billed
+func (u Usage) Total() int {
+	return u.PromptTokens + u.ResponseTokens
+}
+
+// ModelPrice is what a model charges, in US dollars per million tokens
+type ModelPrice struct {
+	Prompt   float64
+	Response float64
+}
+
+// Prices lists the published prices of the models we use. Unknown models
+// are counted at zero cost.
+var Prices = map[string]ModelPrice{
+	"gemini-2.0-flash":      {Prompt: 0.10, Response: 0.40},
+	"gemini-2.0-flash-lite": {Prompt: 0.075, Response: 0.30},
+	"gemini-1.5-flash":      {Prompt: 0.075, Response: 0.30},
+	"gemini-1.5-pro":        {Prompt: 1.25, Response: 5.00},
+}
+
+// Cost estimates what a call cost on a model
+func (p ModelPrice) Cost(u Usage) float64 {
+	return (float64(u.PromptTokens)*p.Prompt + float64(u.ResponseTokens)*p.Response) / 1_000_000
+}
+
+// SessionUsage totals the narrator calls made this session
+type SessionUsage struct {
+	Model   string
+	Calls   int           // Narrator replies asked for
+	Offline int           // Calls answered with the offline description
+	Tokens  Usage         // Tokens billed, retries included; cache hits and replays are free
+	Latency time.Duration // Time spent waiting, summed over calls
+	Cost    float64       // Estimated US dollars
+	Budget  int           // Token limit for the session, 0 if unlimited
+}
+
+// AverageLatency returns how long a call took on average
+func (s SessionUsage) AverageLatency() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Calls)
+}
+
+// BudgetSpent reports whether the session has used up its token budget
+func (s SessionUsage) BudgetSpent() bool {
+	return s.Budget > 0 && s.Tokens.Total() >= s.Budget
+}
+
+// Usage returns the totals for this session so far
+func (c *Client) Usage() SessionUsage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	usage := c.usage
+	usage.Model = c.ModelName
+	usage.Budget = c.TokenBudget
+	return usage
+}
+
+// addTokens records the tokens billed for one generator call
+func (c *Client) addTokens(u Usage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usage.Tokens.PromptTokens += u.PromptTokens
+	c.usage.Tokens.ResponseTokens += u.ResponseTokens
+	c.usage.Cost += Prices[c.ModelName].Cost(u)
+}
+
+// addCall records a finished narrator call
+func (c *Client) addCall(latency time.Duration, offline bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usage.Calls++
+	c.usage.Latency += latency
+	if offline {
+		c.usage.Offline++
+	}
+}
+
+// logUsage writes the session totals to the log
+func (c *Client) logUsage() {
+	u := c.Usage()
+	log.Printf("LLM usage: %d calls, %d prompt + %d response tokens, avg latency %s, est. cost $%.4f",
+		u.Calls, u.Tokens.PromptTokens, u.Tokens.ResponseTokens, u.AverageLatency().Round(time.Millisecond), u.Cost)
+}
@@ -0,0 +1,66 @@
+package llm
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestModelPriceCost(t *testing.T) {
+	price := Prices["gemini-2.0-flash"]
+	got := price.Cost(Usage{PromptTokens: 1_000_000, ResponseTokens: 500_000})
+	if want := 0.30; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Cost() = %f, want %f", got, want)
+	}
+}
+
+func TestUsageTotals(t *testing.T) {
+	generator := &fakeGenerator{reply: "Shelves loom in the dark.", usage: Usage{PromptTokens: 400, ResponseTokens: 100}}
+	client := newTestClient(generator)
+	client.ModelName = "gemini-2.0-flash"
+
+	for range 3 {
+		if _, err := client.GenerateResponse(context.Background(), "look around", playTo()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	usage := client.Usage()
+	if usage.Calls != 3 || usage.Offline != 0 {
+		t.Errorf("Calls, Offline = %d, %d; want 3, 0", usage.Calls, usage.Offline)
+	}
+	if usage.Tokens != (Usage{PromptTokens: 1200, ResponseTokens: 300}) {
+		t.Errorf("Tokens = %+v, want 1200 prompt and 300 response", usage.Tokens)
+	}
+	if want := Prices["gemini-2.0-flash"].Cost(usage.Tokens); math.Abs(usage.Cost-want) > 1e-12 {
+		t.Errorf("Cost = %f, want %f", usage.Cost, want)
+	}
+}
+
+func TestTokenBudget(t *testing.T) {
+	generator := &fakeGenerator{reply: "Shelves loom in the dark.", usage: Usage{PromptTokens: 400, ResponseTokens: 100}}
+	client := newTestClient(generator)
+	client.TokenBudget = 900
+	gs := playTo()
+
+	replies := []string{}
+	for range 3 {
+		reply, err := client.GenerateResponse(context.Background(), "look around", gs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replies = append(replies, reply)
+	}
+
+	// Two calls reach the budget; the third is answered offline
+	if generator.calls != 2 {
+		t.Errorf("generator called %d times, want 2", generator.calls)
+	}
+	if want := gs.DescribeOffline("around"); replies[2] != want {
+		t.Errorf("reply over budget = %q, want the offline description", replies[2])
+	}
+	usage := client.Usage()
+	if !usage.BudgetSpent() || usage.Offline != 1 {
+		t.Errorf("BudgetSpent, Offline = %v, %d; want true, 1", usage.BudgetSpent(), usage.Offline)
+	}
+}
@@ -1,18 +1,46 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
+	"blackoutbargain/game"
 	"blackoutbargain/llm"
+	"blackoutbargain/profile"
+	"blackoutbargain/repl"
+	"blackoutbargain/script"
 	"blackoutbargain/tui"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 )
 
 // --- Main Function ---
 func main() {
+	// Subcommands run without the TUI or the LLM
+	if len(os.Args) > 1 && os.Args[1] == "graph" {
+		os.Exit(runGraph(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(runScript(os.Args[2:]))
+	}
+
+	plain := flag.Bool("plain", false, "play in plain text over stdin/stdout (no colors or full-screen UI)")
+	promptsDir := flag.String("prompts", "", "directory of narrator prompt templates that override the built-in ones")
+	llmTimeout := flag.Duration("llm-timeout", llm.DefaultTimeout, "how long to wait for the narrator before falling back to basic descriptions")
+	cacheDir := flag.String("llm-cache", "", "directory to keep narrator replies in between runs (default: this run only)")
+	recordPath := flag.String("record", "", "save every narrator exchange to this cassette file")
+	replayPath := flag.String("replay", "", "answer from this cassette file instead of calling Gemini")
+	tokenBudget := flag.Int("token-budget", 0, "narrator tokens to spend this session before switching to basic descriptions (0 for no limit)")
+	safety := flag.String("safety", "", "narrator safety thresholds, e.g. dangerous=none,harassment=medium (levels: low, medium, high, none)")
+	flag.Parse()
+	// Piped output can't show the full-screen UI
+	if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		*plain = true
+	}
+
 	// Set up logging
 	logFile, err := os.OpenFile("blackout_bargain.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -33,6 +61,26 @@ func main() {
 		}
 	}()
 
+	llmClient.Timeout = *llmTimeout
+	llmClient.TokenBudget = *tokenBudget
+	if *safety != "" {
+		thresholds, err := llm.ParseSafetyThresholds(*safety)
+		if err != nil {
+			fmt.Printf("Error in -safety: %v\n", err)
+			os.Exit(1)
+		}
+		llmClient.SetSafetyThresholds(thresholds)
+	}
+	if *promptsDir != "" {
+		prompts, err := llm.LoadPrompts(*promptsDir)
+		if err != nil {
+			fmt.Printf("Error loading prompt templates: %v\n", err)
+			os.Exit(1)
+		}
+		llmClient.Prompts = prompts
+	}
+	log.Printf("Narrator prompts version %s (%s)", llmClient.Prompts.Version, llmClient.Prompts.Source)
+
 	// Check if LLM initialization failed critically
 	if os.Getenv("GEMINI_API_KEY") != "" && llmClient != nil && !llmClient.Enabled {
 		fmt.Println("Error initializing LLM - check API key and permissions. Exiting.")
@@ -40,8 +88,63 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Replay needs no API key; otherwise cache, and optionally record, live replies
+	if *replayPath != "" {
+		if err := llmClient.Replay(*replayPath); err != nil {
+			fmt.Printf("Error loading cassette: %v\n", err)
+			os.Exit(1)
+		}
+	} else if llmClient.Enabled {
+		llmClient.UseCache(*cacheDir)
+		if *recordPath != "" {
+			llmClient.Record(*recordPath)
+		}
+	}
+
+	// Let the log and the narrator follow what happens in the game
+	gs := game.NewGameState()
+	gs.Subscribe(func(e game.Event) {
+		log.Printf("Game event: %s", e)
+	})
+	if llmClient != nil {
+		gs.Subscribe(llmClient.Observe)
+	}
+
+	if *plain {
+		if err := runPlain(gs, llmClient); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading commands: %v\n", err)
+			log.Printf("Error in plain mode: %v", err)
+			os.Exit(1)
+		}
+		log.Println("Blackout Bargain finished.")
+		return
+	}
+
 	// Initialize the TUI model
 	m := tui.New(llmClient)
+	m.GameState = gs
+	if path := tui.DefaultHistoryPath(); path != "" {
+		history, err := tui.LoadHistory(path)
+		if err != nil {
+			log.Printf("Error loading command history: %v", err)
+		}
+		m.History = history
+	}
+	if dir := profile.DefaultDir(); dir != "" {
+		m.HighScorePath = profile.HighScoresPath(dir)
+		scores, err := profile.LoadHighScores(m.HighScorePath)
+		if err != nil {
+			log.Printf("Error loading high scores: %v", err)
+		}
+		m.HighScores = scores
+
+		m.AchievementsPath = profile.AchievementsPath(dir)
+		achievements, err := profile.LoadAchievements(m.AchievementsPath)
+		if err != nil {
+			log.Printf("Error loading achievements: %v", err)
+		}
+		m.Achievements = achievements
+	}
 
 	// Create and run the Bubble Tea program
 	// Using AltScreen helps restore the terminal state on exit
@@ -55,3 +158,68 @@ func main() {
 
 	log.Println("Blackout Bargain finished.")
 }
+
+// runPlain plays the game as a plain text REPL over stdin and stdout.
+func runPlain(gs *game.GameState, llmClient *llm.Client) error {
+	session := repl.New(gs, nil, os.Stdout)
+	if llmClient != nil && llmClient.Enabled {
+		session.Narrator = llmClient
+	}
+	// Echo commands that aren't typed so transcripts read like a session
+	session.Echo = !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd())
+
+	return session.Run(os.Stdin)
+}
+
+// runScript plays a walkthrough script against a new game, printing the
+// transcript and any checks that failed.
+func runScript(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	path := fs.String("script", "", "walkthrough script to play")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "Usage: blackoutbargain run --script walkthrough.txt")
+		return 2
+	}
+
+	steps, err := script.ParseFile(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading script: %v\n", err)
+		return 1
+	}
+	failures := script.Run(game.NewGameState(), steps, os.Stdout)
+	for _, failure := range failures {
+		fmt.Fprintf(os.Stderr, "FAIL %s: %v\n", *path, failure)
+	}
+	if len(failures) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runGraph prints the world map as a DOT or Mermaid diagram for design review.
+func runGraph(args []string) int {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	format := fs.String("format", "dot", "diagram format: dot or mermaid")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var err error
+	switch *format {
+	case "dot":
+		err = game.WriteDOT(os.Stdout)
+	case "mermaid":
+		err = game.WriteMermaid(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown graph format %q (use dot or mermaid)\n", *format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing graph: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,150 @@
+package profile
+
+import (
+	"path/filepath"
+	"slices"
+	"time"
+
+	"blackoutbargain/game"
+)
+
+// --- Achievements ---
+
+// speedrunTurns is the turn count a run must beat for the speedrun achievement
+const speedrunTurns = 20
+
+// Achievement is a goal that stays unlocked across playthroughs
+type Achievement struct {
+	ID          string
+	Title       string
+	Description string
+	unlocked    func(gs *game.GameState, a *Achievements) bool
+}
+
+// AchievementList defines every achievement in display order
+var AchievementList = []Achievement{
+	{
+		ID:          "escaped",
+		Title:       "Out of the Storm",
+		Description: "Escape the store.",
+		unlocked:    func(gs *game.GameState, a *Achievements) bool { return gs.GameOver },
+	},
+	{
+		ID:          "speedrun",
+		Title:       "Clearance Sprint",
+		Description: "Escape in under 20 turns.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			return gs.GameOver && gs.Stats.Turns < speedrunTurns
+		},
+	},
+	{
+		ID:          "unplugged",
+		Title:       "Unplugged",
+		Description: "Escape without asking the narrator anything.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			return gs.GameOver && gs.Stats.LLMCalls == 0
+		},
+	},
+	{
+		ID:          "no_hints",
+		Title:       "Gut Instinct",
+		Description: "Escape without using a hint.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			return gs.GameOver && gs.HintsUsed() == 0
+		},
+	},
+	{
+		ID:          "steady_hand",
+		Title:       "Steady Hand",
+		Description: "Escape without entering a wrong code.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			return gs.GameOver && gs.Stats.WrongCodes == 0
+		},
+	},
+	{
+		ID:          "thorough",
+		Title:       "No Stone Unturned",
+		Description: "Find every clue in a single playthrough.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			return len(gs.Evidence) == len(game.ClueCatalog)
+		},
+	},
+	{
+		ID:          "justice",
+		Title:       "Case Closed",
+		Description: "Accuse the killer and escape.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			return gs.Ending() == game.EndingJustice
+		},
+	},
+	{
+		ID:          "every_ending",
+		Title:       "Every Way Out",
+		Description: "See every ending.",
+		unlocked: func(gs *game.GameState, a *Achievements) bool {
+			for _, ending := range game.Endings {
+				if !slices.Contains(a.Endings, ending) {
+					return false
+				}
+			}
+			return true
+		},
+	},
+}
+
+// Achievements is the player's progress, kept between playthroughs
+type Achievements struct {
+	Unlocked map[string]time.Time `json:"unlocked"` // Achievement ID to when it was unlocked
+	Endings  []string             `json:"endings"`  // Endings seen in any playthrough
+}
+
+// AchievementsPath returns the achievements file inside dir
+func AchievementsPath(dir string) string {
+	return filepath.Join(dir, "achievements.json")
+}
+
+// NewAchievements returns progress with nothing unlocked
+func NewAchievements() *Achievements {
+	return &Achievements{Unlocked: make(map[string]time.Time)}
+}
+
+// LoadAchievements reads progress from path. A missing file gives fresh progress.
+func LoadAchievements(path string) (*Achievements, error) {
+	a := NewAchievements()
+	if err := readJSON(path, a); err != nil {
+		return NewAchievements(), err
+	}
+	if a.Unlocked == nil {
+		a.Unlocked = make(map[string]time.Time)
+	}
+	return a, nil
+}
+
+// Save writes progress to path, creating its directory if needed
+func (a *Achievements) Save(path string) error {
+	return writeJSON(path, a)
+}
+
+// IsUnlocked reports whether an achievement has been earned
+func (a *Achievements) IsUnlocked(id string) bool {
+	_, ok := a.Unlocked[id]
+	return ok
+}
+
+// Evaluate records the game's ending, if any, and unlocks every achievement
+// the game now satisfies. Returns the ones unlocked by this call.
+func (a *Achievements) Evaluate(gs *game.GameState) []Achievement {
+	if ending := gs.Ending(); ending != "" && !slices.Contains(a.Endings, ending) {
+		a.Endings = append(a.Endings, ending)
+	}
+
+	newlyUnlocked := []Achievement{}
+	for _, achievement := range AchievementList {
+		if a.IsUnlocked(achievement.ID) || !achievement.unlocked(gs, a) {
+			continue
+		}
+		a.Unlocked[achievement.ID] = time.Now()
+		newlyUnlocked = append(newlyUnlocked, achievement)
+	}
+	return newlyUnlocked
+}
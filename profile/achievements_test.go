@@ -0,0 +1,109 @@
+package profile
+
+import (
+	"testing"
+
+	"blackoutbargain/game"
+)
+
+// unlockedIDs returns the IDs of the given achievements
+func unlockedIDs(achievements []Achievement) []string {
+	ids := []string{}
+	for _, a := range achievements {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestEvaluateAchievements(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(gs *game.GameState)
+		expected []string
+	}{
+		{
+			name:     "still playing",
+			setup:    func(gs *game.GameState) {},
+			expected: []string{},
+		},
+		{
+			name: "quick clean escape",
+			setup: func(gs *game.GameState) {
+				gs.GameOver = true
+				gs.Stats.Turns = 15
+			},
+			expected: []string{"escaped", "speedrun", "unplugged", "no_hints", "steady_hand"},
+		},
+		{
+			name: "slow escape with help",
+			setup: func(gs *game.GameState) {
+				gs.GameOver = true
+				gs.Accused = game.Killer
+				gs.Stats.Turns = 40
+				gs.Stats.LLMCalls = 3
+				gs.Stats.WrongCodes = 1
+				gs.Hints["find_dale"] = 1
+			},
+			expected: []string{"escaped", "justice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := game.NewGameState()
+			tt.setup(gs)
+			got := unlockedIDs(NewAchievements().Evaluate(gs))
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Evaluate() unlocked %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Evaluate() unlocked %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestEveryEndingAcrossPlaythroughs(t *testing.T) {
+	a := NewAchievements()
+	for _, accused := range []string{"", "Brenda", game.Killer} {
+		if a.IsUnlocked("every_ending") {
+			t.Fatalf("every_ending unlocked before seeing all endings (seen %v)", a.Endings)
+		}
+		gs := game.NewGameState()
+		gs.GameOver = true
+		gs.Accused = accused
+		a.Evaluate(gs)
+	}
+	if !a.IsUnlocked("every_ending") {
+		t.Errorf("every_ending not unlocked after endings %v", a.Endings)
+	}
+}
+
+func TestAchievementsRoundTrip(t *testing.T) {
+	path := AchievementsPath(t.TempDir())
+
+	a, err := LoadAchievements(path)
+	if err != nil {
+		t.Fatalf("LoadAchievements() on missing file error = %v", err)
+	}
+	gs := game.NewGameState()
+	gs.GameOver = true
+	a.Evaluate(gs)
+	if err := a.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadAchievements(path)
+	if err != nil {
+		t.Fatalf("LoadAchievements() error = %v", err)
+	}
+	if !loaded.IsUnlocked("escaped") || len(loaded.Endings) != 1 {
+		t.Errorf("loaded = %+v, want escaped unlocked and one ending", loaded)
+	}
+	if again := loaded.Evaluate(gs); len(again) != 0 {
+		t.Errorf("Evaluate() after reload unlocked %v again", unlockedIDs(again))
+	}
+}
@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// --- High Score Table ---
+
+// maxHighScores is how many entries the table keeps
+const maxHighScores = 10
+
+// HighScore is one finished playthrough in the table
+type HighScore struct {
+	Score     int           `json:"score"`
+	Escaped   bool          `json:"escaped"`
+	Clues     int           `json:"clues"`
+	Turns     int           `json:"turns"`
+	HintsUsed int           `json:"hints_used"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Date      time.Time     `json:"date"`
+}
+
+// HighScoreTable is the local leaderboard, best score first
+type HighScoreTable struct {
+	Entries []HighScore `json:"entries"`
+}
+
+// DefaultDir returns the directory profile files are kept in
+func DefaultDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "blackoutbargain")
+}
+
+// HighScoresPath returns the high score file inside dir
+func HighScoresPath(dir string) string {
+	return filepath.Join(dir, "highscores.json")
+}
+
+// LoadHighScores reads the table from path. A missing file gives an empty table.
+func LoadHighScores(path string) (*HighScoreTable, error) {
+	table := &HighScoreTable{}
+	if err := readJSON(path, table); err != nil {
+		return &HighScoreTable{}, err
+	}
+	return table, nil
+}
+
+// Add inserts an entry and returns its 1-based rank, or 0 if it didn't make the table
+func (t *HighScoreTable) Add(entry HighScore) int {
+	t.Entries = append(t.Entries, entry)
+	sort.SliceStable(t.Entries, func(i, j int) bool {
+		return t.Entries[i].Score > t.Entries[j].Score
+	})
+
+	rank := 0
+	for i := range t.Entries {
+		if t.Entries[i] == entry {
+			rank = i + 1
+			break
+		}
+	}
+	if len(t.Entries) > maxHighScores {
+		t.Entries = t.Entries[:maxHighScores]
+	}
+	if rank > maxHighScores {
+		return 0
+	}
+	return rank
+}
+
+// Save writes the table to path, creating its directory if needed
+func (t *HighScoreTable) Save(path string) error {
+	return writeJSON(path, t)
+}
+
+// readJSON decodes a file into v, leaving v untouched if the file doesn't exist
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeJSON encodes v to a file, replacing it atomically
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,47 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHighScoreTableAdd(t *testing.T) {
+	table := &HighScoreTable{}
+	for score := 100; score <= 1000; score += 100 {
+		table.Add(HighScore{Score: score})
+	}
+
+	if rank := table.Add(HighScore{Score: 550}); rank != 6 {
+		t.Errorf("Add(550) rank = %d, want 6", rank)
+	}
+	if len(table.Entries) != maxHighScores {
+		t.Errorf("len(Entries) = %d, want %d", len(table.Entries), maxHighScores)
+	}
+	if rank := table.Add(HighScore{Score: 50}); rank != 0 {
+		t.Errorf("Add(50) rank = %d, want 0 (off the table)", rank)
+	}
+	if table.Entries[0].Score != 1000 {
+		t.Errorf("best score = %d, want 1000", table.Entries[0].Score)
+	}
+}
+
+func TestHighScoresRoundTrip(t *testing.T) {
+	path := HighScoresPath(filepath.Join(t.TempDir(), "nested"))
+
+	table, err := LoadHighScores(path)
+	if err != nil {
+		t.Fatalf("LoadHighScores() on missing file error = %v", err)
+	}
+	table.Add(HighScore{Score: 720, Escaped: true, Turns: 25})
+	if err := table.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadHighScores(path)
+	if err != nil {
+		t.Fatalf("LoadHighScores() error = %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0].Score != 720 || loaded.Entries[0].Turns != 25 {
+		t.Errorf("loaded entries = %+v, want one entry with score 720 and 25 turns", loaded.Entries)
+	}
+}
@@ -0,0 +1,176 @@
+package repl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"blackoutbargain/game"
+)
+
+// --- Plain Line Mode ---
+
+// Narrator answers the commands the engine leaves to the LLM
+type Narrator interface {
+	GenerateResponse(ctx context.Context, playerInput string, gameState *game.GameState) (string, error)
+}
+
+// Session plays the game as a classic read-eval-print loop over plain text,
+// for screen readers and for piping command files through the engine
+type Session struct {
+	GameState *game.GameState
+	Narrator  Narrator // Optional; without it every command goes to the engine
+	Echo      bool     // Print each command after the prompt, for when input isn't typed
+
+	out io.Writer
+}
+
+// New creates a session that writes to out
+func New(gs *game.GameState, narrator Narrator, out io.Writer) *Session {
+	return &Session{GameState: gs, Narrator: narrator, out: out}
+}
+
+// Run reads commands from in until the player escapes, quits or the input
+// runs out
+func (s *Session) Run(in io.Reader) error {
+	s.println("Blackout Bargain")
+	s.println("Type 'help' for commands, 'quit' to leave.")
+	s.println("")
+	s.printStatus()
+
+	scanner := bufio.NewScanner(in)
+	for !s.GameState.GameOver {
+		s.printf("\n%s", s.prompt())
+		if !scanner.Scan() {
+			s.println("")
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if s.Echo {
+			s.println(line)
+		}
+		if line == "quit" || line == "exit" {
+			s.println("You stay put in the dark. Goodbye.")
+			return nil
+		}
+		s.Execute(line)
+	}
+	return nil
+}
+
+// Execute runs a single command and prints what happened
+func (s *Session) Execute(line string) {
+	if line == "" {
+		s.println("Please enter a command.")
+		return
+	}
+
+	switch s.GameState.RouteCommand(line) {
+	case game.RouteNotebook:
+		s.printNotebook()
+		return
+	case game.RouteNarrator:
+		if s.Narrator != nil {
+			s.narrate(line)
+			return
+		}
+	}
+
+	result := s.GameState.HandleCommand(line)
+	for _, msg := range result.Messages {
+		s.println(msg.Text)
+	}
+	for _, event := range result.Events {
+		switch event := event.(type) {
+		case game.LocationEntered:
+			s.println("")
+			s.printStatus()
+		case game.ClueDiscovered:
+			s.printf("New clue noted: %s. Type 'notebook' to review your clues.\n", event.Clue.Title)
+		case game.GameEnded:
+			s.printScore()
+		}
+	}
+}
+
+// narrate hands a command to the LLM and prints its reply
+func (s *Session) narrate(line string) {
+	s.GameState.RecordNarratedTurn()
+	response, err := s.Narrator.GenerateResponse(context.Background(), line, s.GameState)
+	if err != nil {
+		s.printf("LLM API Error: %s\n", err)
+		return
+	}
+	s.println(PlainText(response))
+}
+
+// Markdown the narrator uses, and what it becomes in plain text
+var (
+	strongPattern   = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	emphasisPattern = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*|\b_(\S(?:.*?\S)?)_\b`)
+	headingPattern  = regexp.MustCompile(`(?m)^#{1,6}\s+`)
+	bulletPattern   = regexp.MustCompile(`(?m)^(\s*)[*+]\s+`)
+)
+
+// PlainText flattens the narrator's Markdown for screen readers and pipes:
+// emphasis markers and heading marks go, list items keep a plain dash
+func PlainText(markdown string) string {
+	text := bulletPattern.ReplaceAllString(markdown, "$1- ")
+	text = headingPattern.ReplaceAllString(text, "")
+	text = strongPattern.ReplaceAllString(text, "$1$2")
+	return emphasisPattern.ReplaceAllString(text, "$1$2")
+}
+
+// prompt returns the text shown before each command
+func (s *Session) prompt() string {
+	if s.GameState.InputRequired != "" {
+		return fmt.Sprintf("Enter %s: ", strings.ReplaceAll(s.GameState.InputRequired, "_", " "))
+	}
+	return "> "
+}
+
+// printStatus describes the location, its exits and items, and the inventory
+func (s *Session) printStatus() {
+	s.println(s.GameState.GetLocationDescription())
+	if exits := s.GameState.GetExitsDescription(); exits != "" {
+		s.println(exits)
+	}
+	if items := s.GameState.GetVisibleItems(); items != "" {
+		s.println(items)
+	}
+	s.println(s.GameState.GetInventoryDescription())
+}
+
+// printNotebook lists the clues found so far
+func (s *Session) printNotebook() {
+	if len(s.GameState.Evidence) == 0 {
+		s.println("You haven't found any clues yet. Explore the store and pick things up.")
+		return
+	}
+	s.println("Detective Notebook:")
+	for i, ev := range s.GameState.Evidence {
+		s.printf("%d. %s (from %s): %s\n", i+1, ev.Title, ev.Source, ev.Details)
+	}
+}
+
+// printScore shows the end-of-game score breakdown
+func (s *Session) printScore() {
+	score := s.GameState.Score()
+	s.println("")
+	s.println("Score:")
+	for _, line := range score.Lines {
+		s.printf("%s, %s: %d\n", line.Label, line.Detail, line.Points)
+	}
+	s.printf("Total: %d\n", score.Total)
+}
+
+func (s *Session) println(text string) {
+	fmt.Fprintln(s.out, text)
+}
+
+func (s *Session) printf(format string, args ...any) {
+	fmt.Fprintf(s.out, format, args...)
+}
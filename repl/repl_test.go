@@ -0,0 +1,122 @@
+package repl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"blackoutbargain/game"
+)
+
+// fakeNarrator answers every command with the same line
+type fakeNarrator struct {
+	response string
+	err      error
+	calls    int
+}
+
+func (f *fakeNarrator) GenerateResponse(ctx context.Context, playerInput string, gameState *game.GameState) (string, error) {
+	f.calls++
+	return f.response, f.err
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		narrator *fakeNarrator
+		expected []string
+	}{
+		{
+			name:  "moves and describes the new room",
+			input: "n\n",
+			expected: []string{
+				"> You hurry towards the back of the store",
+				"Exits: east to Employee Locker Area",
+				"New clue noted: Puncture wound.",
+			},
+		},
+		{
+			name:     "asks for codes at locks",
+			input:    "n\ne\nuse 8675309\n8675309\n",
+			expected: []string{"Enter locker code: Click! The locker swings open."},
+		},
+		{
+			name:     "narrates with an LLM",
+			input:    "look\n",
+			narrator: &fakeNarrator{response: "Shadows pool under the registers."},
+			expected: []string{"> Shadows pool under the registers."},
+		},
+		{
+			name:     "reports narrator errors",
+			input:    "look\n",
+			narrator: &fakeNarrator{err: errors.New("quota exceeded")},
+			expected: []string{"LLM API Error: quota exceeded"},
+		},
+		{
+			name:     "quits",
+			input:    "quit\nn\n",
+			expected: []string{"Goodbye."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			session := New(game.NewGameState(), nil, &out)
+			if tt.narrator != nil {
+				session.Narrator = tt.narrator
+			}
+			if err := session.Run(strings.NewReader(tt.input)); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+			if strings.Contains(out.String(), "\x1b[") {
+				t.Errorf("output contains ANSI escapes:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestRunEndsOnEscape(t *testing.T) {
+	gs := game.NewGameState()
+	gs.Location = game.LocLoadingDock
+	gs.Clues["door_unlocked"] = "true"
+
+	var out strings.Builder
+	if err := New(gs, nil, &out).Run(strings.NewReader("escape\nn\n")); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(out.String(), game.EscapeNarrative) || !strings.Contains(out.String(), "Total: ") {
+		t.Errorf("output missing ending and score:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "You can't find a way") {
+		t.Errorf("commands after escaping were run:\n%s", out.String())
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		markdown string
+		expected string
+	}{
+		{"Shadows pool under the registers.", "Shadows pool under the registers."},
+		{"Brenda grips the counter. *Hard.*", "Brenda grips the counter. Hard."},
+		{`"Dale was **my friend**," she whispers.`, `"Dale was my friend," she whispers.`},
+		{"__Dead__ silence, _then_ thunder.", "Dead silence, then thunder."},
+		{"## The Office\n* A safe\n+ A corkboard", "The Office\n- A safe\n- A corkboard"},
+		{"Aisle 13 * 2 = 26", "Aisle 13 * 2 = 26"},
+		{"dale_notebook stays", "dale_notebook stays"},
+	}
+
+	for _, tt := range tests {
+		if got := PlainText(tt.markdown); got != tt.expected {
+			t.Errorf("PlainText(%q) = %q, want %q", tt.markdown, got, tt.expected)
+		}
+	}
+}
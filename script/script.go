@@ -0,0 +1,165 @@
+package script
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"blackoutbargain/game"
+	"blackoutbargain/repl"
+)
+
+// --- Walkthrough Scripts ---
+//
+// A script reads like a plain mode transcript:
+//
+//	# Comments start with a hash
+//	> go security                 A command, exactly as the player would type it
+//	You hurry towards the back    Text the command's output must contain
+//	@ location security           A check on the game state afterwards
+//
+// Code entries are ordinary commands on the line after the one that asks
+// for the code. State checks are:
+//
+//	@ location <id>      The player is in the location with this ID
+//	@ has <item>         The item is in the inventory
+//	@ clue <flag>        The flag is set in Clues
+//	@ evidence <id>      The clue has been discovered
+//	@ prompt <code>      The game is waiting for this code ("none" if not)
+//	@ turns <n>          Exactly n turns have been taken
+//	@ over               The player has escaped
+
+// StepKind says what a script line does
+type StepKind int
+
+const (
+	StepCommand StepKind = iota // Run a command
+	StepExpect                  // Check the last command's output
+	StepState                   // Check the game state
+)
+
+// Step is a single line of a script
+type Step struct {
+	Line int // Line number in the script, for failure messages
+	Kind StepKind
+	Text string
+}
+
+// Failure is a step whose check didn't hold
+type Failure struct {
+	Step    Step
+	Command string // Last command run before the step
+	Reason  string
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("line %d (after %q): %s", f.Step.Line, f.Command, f.Reason)
+}
+
+// stateChecks are the state assertions a script can make
+var stateChecks = map[string]func(gs *game.GameState, arg string) (bool, string){
+	"location": func(gs *game.GameState, arg string) (bool, string) {
+		return gs.Location.ID() == arg, "location is " + gs.Location.ID()
+	},
+	"has": func(gs *game.GameState, arg string) (bool, string) {
+		item, ok := game.FindItem(arg)
+		return ok && gs.Inventory[item], gs.GetInventoryDescription()
+	},
+	"clue": func(gs *game.GameState, arg string) (bool, string) {
+		_, ok := gs.Clues[arg]
+		return ok, fmt.Sprintf("clues are %v", gs.Clues)
+	},
+	"evidence": func(gs *game.GameState, arg string) (bool, string) {
+		return gs.HasEvidence(arg), fmt.Sprintf("%d clue(s) found", len(gs.Evidence))
+	},
+	"prompt": func(gs *game.GameState, arg string) (bool, string) {
+		prompt := gs.InputRequired
+		if prompt == "" {
+			prompt = "none"
+		}
+		return prompt == arg, "prompt is " + prompt
+	},
+	"turns": func(gs *game.GameState, arg string) (bool, string) {
+		return strconv.Itoa(gs.Stats.Turns) == arg, fmt.Sprintf("%d turns taken", gs.Stats.Turns)
+	},
+	"over": func(gs *game.GameState, arg string) (bool, string) {
+		return gs.GameOver, "the game is still going"
+	},
+}
+
+// Parse reads a script
+func Parse(r io.Reader) ([]Step, error) {
+	var steps []Step
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+			continue
+		case strings.HasPrefix(text, ">"):
+			steps = append(steps, Step{Line: line, Kind: StepCommand, Text: strings.TrimSpace(text[1:])})
+		case strings.HasPrefix(text, "@"):
+			check := strings.TrimSpace(text[1:])
+			name, _, _ := strings.Cut(check, " ")
+			if _, ok := stateChecks[name]; !ok {
+				return nil, fmt.Errorf("line %d: unknown state check %q", line, name)
+			}
+			steps = append(steps, Step{Line: line, Kind: StepState, Text: check})
+		default:
+			steps = append(steps, Step{Line: line, Kind: StepExpect, Text: text})
+		}
+	}
+	return steps, scanner.Err()
+}
+
+// ParseFile reads a script from a file
+func ParseFile(path string) ([]Step, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Run plays the steps against the game, writing a plain mode transcript of
+// each command and its output, and returns every check that failed
+func Run(gs *game.GameState, steps []Step, transcript io.Writer) []Failure {
+	var output strings.Builder
+	session := repl.New(gs, nil, &output)
+
+	var failures []Failure
+	lastCommand := ""
+	for _, step := range steps {
+		switch step.Kind {
+		case StepCommand:
+			output.Reset()
+			session.Execute(step.Text)
+			lastCommand = step.Text
+			fmt.Fprintf(transcript, "> %s\n%s", step.Text, output.String())
+
+		case StepExpect:
+			if !strings.Contains(output.String(), step.Text) {
+				failures = append(failures, Failure{
+					Step:    step,
+					Command: lastCommand,
+					Reason:  fmt.Sprintf("output doesn't contain %q; got:\n%s", step.Text, output.String()),
+				})
+			}
+
+		case StepState:
+			name, arg, _ := strings.Cut(step.Text, " ")
+			if ok, actual := stateChecks[name](gs, strings.TrimSpace(arg)); !ok {
+				failures = append(failures, Failure{
+					Step:    step,
+					Command: lastCommand,
+					Reason:  fmt.Sprintf("expected %s, but %s", step.Text, actual),
+				})
+			}
+		}
+	}
+	return failures
+}
@@ -0,0 +1,38 @@
+package script
+
+import (
+	"strings"
+	"testing"
+
+	"blackoutbargain/game"
+)
+
+func TestRunReportsFailures(t *testing.T) {
+	steps, err := Parse(strings.NewReader(`
+> go security
+You hurry towards
+The lights come back on
+@ location office
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var transcript strings.Builder
+	failures := Run(game.NewGameState(), steps, &transcript)
+	if len(failures) != 2 {
+		t.Fatalf("Run() failures = %v, want 2", failures)
+	}
+	if failures[0].Step.Line != 4 || failures[1].Step.Line != 5 {
+		t.Errorf("failed lines = %d, %d, want 4, 5", failures[0].Step.Line, failures[1].Step.Line)
+	}
+	if !strings.HasPrefix(transcript.String(), "> go security\nYou hurry towards") {
+		t.Errorf("transcript = %q", transcript.String())
+	}
+}
+
+func TestParseRejectsUnknownChecks(t *testing.T) {
+	if _, err := Parse(strings.NewReader("> n\n@ weather stormy\n")); err == nil {
+		t.Error("Parse() accepted an unknown state check")
+	}
+}
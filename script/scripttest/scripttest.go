@@ -0,0 +1,27 @@
+// Package scripttest runs walkthrough scripts from Go tests. It lives apart
+// from package script so the game binary doesn't link the testing package.
+package scripttest
+
+import (
+	"io"
+	"testing"
+
+	"blackoutbargain/game"
+	"blackoutbargain/script"
+)
+
+// Run plays a script file against a new game from a Go test, reporting each
+// failed check as a test error
+func Run(t testing.TB, path string) *game.GameState {
+	t.Helper()
+	steps, err := script.ParseFile(path)
+	if err != nil {
+		t.Fatalf("reading script %s: %v", path, err)
+	}
+
+	gs := game.NewGameState()
+	for _, failure := range script.Run(gs, steps, io.Discard) {
+		t.Errorf("%s: %v", path, failure)
+	}
+	return gs
+}
@@ -0,0 +1,14 @@
+package scripttest
+
+import (
+	"testing"
+
+	"blackoutbargain/game"
+)
+
+func TestWalkthrough(t *testing.T) {
+	gs := Run(t, "../testdata/walkthrough.txt")
+	if len(gs.Evidence) != len(game.ClueCatalog) {
+		t.Errorf("walkthrough found %d of %d clues", len(gs.Evidence), len(game.ClueCatalog))
+	}
+}
@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"blackoutbargain/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Clue Board (Detective Notebook) ---
+
+// boardOrder returns the discovered clues with pinned ones first, otherwise
+// in the order they were found
+func boardOrder(gs *game.GameState) []game.Evidence {
+	ordered := []game.Evidence{}
+	for _, pinned := range []bool{true, false} {
+		for _, ev := range gs.Evidence {
+			if ev.Pinned == pinned {
+				ordered = append(ordered, ev)
+			}
+		}
+	}
+	return ordered
+}
+
+// updateBoard handles keys while the clue board is open: up/down select a
+// clue, p pins it and the number keys connect it to a suspect
+func (m Model) updateBoard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	clues := boardOrder(m.GameState)
+
+	switch msg.String() {
+	case "esc", "f2":
+		m.ShowingBoard = false
+		return m, nil
+	case "up", "k":
+		m.BoardCursor = max(m.BoardCursor-1, 0)
+		return m, nil
+	case "down", "j":
+		m.BoardCursor = min(m.BoardCursor+1, max(len(clues)-1, 0))
+		return m, nil
+	}
+
+	if m.BoardCursor >= len(clues) {
+		return m, nil
+	}
+	selected := clues[m.BoardCursor]
+
+	switch msg.String() {
+	case "p":
+		m.GameState.TogglePin(selected.ID)
+		// Keep the cursor on the same clue after it moves
+		for i, ev := range boardOrder(m.GameState) {
+			if ev.ID == selected.ID {
+				m.BoardCursor = i
+			}
+		}
+	default:
+		for i, suspect := range game.Suspects {
+			if msg.String() == fmt.Sprint(i+1) {
+				m.GameState.ToggleLink(selected.ID, suspect)
+			}
+		}
+	}
+	return m, nil
+}
+
+// boardView renders the clue list beside the selected clue and the suspects
+func (m Model) boardView() string {
+	var s strings.Builder
+	title := m.Styles.Title.Render("--- Detective Notebook ---")
+	s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Center, title))
+	s.WriteString("\n\n")
+
+	clues := boardOrder(m.GameState)
+	if len(clues) == 0 {
+		s.WriteString(m.Styles.Base.Render(m.Styles.Message.Render("You haven't found any clues yet. Explore the store and pick things up.")))
+		s.WriteString("\n\n")
+		s.WriteString(m.Styles.Help.Render(" F2 or Esc to close."))
+		return s.String()
+	}
+
+	// Clue list
+	listWidth := max(m.Width/3, 24)
+	var list strings.Builder
+	for i, ev := range clues {
+		marker := "  "
+		if ev.Pinned {
+			marker = "* "
+		}
+		line := marker + ev.Title
+		if i == m.BoardCursor {
+			list.WriteString(m.Styles.BoardSelected.Render(line))
+		} else {
+			list.WriteString(m.Styles.Items.Render(line))
+		}
+		list.WriteString("\n")
+	}
+
+	// Selected clue
+	selected := clues[min(m.BoardCursor, len(clues)-1)]
+	detailWidth := max(m.Width-listWidth-6, 20)
+	var detail strings.Builder
+	detail.WriteString(m.Styles.Location.Render(selected.Title))
+	detail.WriteString("\n")
+	detail.WriteString(m.Styles.Help.Render(fmt.Sprintf("Source: %s | Found %s", selected.Source, selected.FoundAt.Format("15:04:05"))))
+	detail.WriteString("\n\n")
+	detail.WriteString(m.Styles.Message.Width(detailWidth - m.Styles.BoardPanel.GetHorizontalPadding()).Render(selected.Details))
+	detail.WriteString("\n\n")
+	linked := "nobody"
+	if len(selected.Linked) > 0 {
+		linked = strings.Join(selected.Linked, ", ")
+	}
+	detail.WriteString(m.Styles.Inventory.Render("Connected to: " + linked))
+
+	// Suspects
+	detail.WriteString("\n\n")
+	detail.WriteString(m.Styles.Location.Render("Suspects"))
+	for i, suspect := range game.Suspects {
+		count := len(m.GameState.EvidenceAgainst(suspect))
+		detail.WriteString(fmt.Sprintf("\n[%d] %s: %d clue(s)", i+1, suspect, count))
+	}
+	if m.GameState.Accused != "" {
+		detail.WriteString(m.Styles.Help.Render("\nYou have accused " + m.GameState.Accused + "."))
+	}
+
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.Styles.BoardPanel.Width(listWidth).Render(strings.TrimSuffix(list.String(), "\n")),
+		m.Styles.BoardPanel.Width(detailWidth).Render(detail.String()),
+	)
+	s.WriteString(columns)
+	s.WriteString("\n\n")
+
+	keys := []string{"Up/Down select", "p pin"}
+	for i, suspect := range game.Suspects {
+		keys = append(keys, fmt.Sprintf("%d link %s", i+1, suspect))
+	}
+	keys = append(keys, "F2 or Esc close", "type 'accuse <name>' to accuse")
+	s.WriteString(m.Styles.Help.Width(m.Width).Render(" " + strings.Join(keys, ", ") + "."))
+	return s.String()
+}
+
+// noteClue adds a transcript line pointing the player to a new clue
+func (m *Model) noteClue(clue game.ClueDefinition) {
+	m.addEntry(EntryEngine, fmt.Sprintf("New clue noted: %s. Press F2 to open your notebook.", clue.Title))
+}
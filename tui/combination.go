@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Combination Dial Widget ---
+
+const (
+	dialWidth  = 5 // Dial width including its border
+	dialHeight = 5 // Arrow, bordered digit (3 lines), arrow
+	dialHeader = 2 // Lines above the dials: title, blank
+)
+
+// Combination is a Bubble Tea component showing a row of numbered dials, used
+// for combination locks like Dale's locker and the office safe. Left/right pick
+// a dial, up/down (or the mouse wheel) turn it, typing a digit sets it and moves
+// on, clicking an arrow turns that dial. Enter sends a FormSubmittedMsg.
+type Combination struct {
+	Title  string
+	Dials  []int
+	Styles Styles
+
+	selected int
+}
+
+// NewCombination creates a lock with the given number of dials, all at zero
+func NewCombination(title string, dials int, styles Styles) Combination {
+	return Combination{Title: title, Dials: make([]int, dials), Styles: styles}
+}
+
+func (c Combination) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses and mouse input. Mouse coordinates must be
+// relative to the top-left corner of the combination's View.
+func (c Combination) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	c.Dials = append([]int{}, c.Dials...) // Copy so earlier model values are untouched
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyLeft:
+			c.selected = max(c.selected-1, 0)
+		case tea.KeyRight, tea.KeyTab:
+			c.selected = min(c.selected+1, len(c.Dials)-1)
+		case tea.KeyUp:
+			c.turn(c.selected, 1)
+		case tea.KeyDown:
+			c.turn(c.selected, -1)
+		case tea.KeyBackspace:
+			c.Dials[c.selected] = 0
+			c.selected = max(c.selected-1, 0)
+		case tea.KeyEnter:
+			value := c.Value()
+			return c, func() tea.Msg { return FormSubmittedMsg{Value: value} }
+		case tea.KeyRunes:
+			for _, r := range msg.Runes {
+				if r >= '0' && r <= '9' {
+					c.Dials[c.selected] = int(r - '0')
+					c.selected = min(c.selected+1, len(c.Dials)-1)
+				}
+			}
+		}
+
+	case tea.MouseMsg:
+		dial := msg.X / dialWidth
+		if msg.X < 0 || dial >= len(c.Dials) || msg.Y < dialHeader || msg.Y >= dialHeader+dialHeight {
+			return c, nil
+		}
+		switch {
+		case msg.Button == tea.MouseButtonWheelUp:
+			c.turn(dial, 1)
+		case msg.Button == tea.MouseButtonWheelDown:
+			c.turn(dial, -1)
+		case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+			switch msg.Y - dialHeader {
+			case 0: // Up arrow
+				c.turn(dial, 1)
+			case dialHeight - 1: // Down arrow
+				c.turn(dial, -1)
+			}
+		default:
+			return c, nil
+		}
+		c.selected = dial
+	}
+	return c, nil
+}
+
+// turn rotates a dial, wrapping between 9 and 0
+func (c *Combination) turn(dial, step int) {
+	c.Dials[dial] = (c.Dials[dial] + step + 10) % 10
+}
+
+// Value returns the digits currently showing on the dials
+func (c Combination) Value() string {
+	var sb strings.Builder
+	for _, digit := range c.Dials {
+		sb.WriteString(fmt.Sprint(digit))
+	}
+	return sb.String()
+}
+
+func (c Combination) View() string {
+	dials := make([]string, len(c.Dials))
+	for i, digit := range c.Dials {
+		style := c.Styles.KeypadKey
+		arrows := c.Styles.Help
+		if i == c.selected {
+			style = c.Styles.KeypadKeySelected
+			arrows = c.Styles.Prompt
+		}
+		face := style.Width(dialWidth - 2).Render(fmt.Sprint(digit))
+		dials[i] = lipgloss.JoinVertical(lipgloss.Center, arrows.Render("▲"), face, arrows.Render("▼"))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(c.Styles.Prompt.Render(c.Title))
+	sb.WriteString("\n\n")
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, dials...))
+	sb.WriteString("\n\n")
+	sb.WriteString(c.Styles.Help.Render("Left/Right pick a dial, Up/Down or wheel turn it,\ntype digits to set them, Enter to try the lock"))
+	return sb.String()
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"blackoutbargain/llm"
+)
+
+// --- LLM Debug Overlay ---
+
+// debugView renders the narrator's usage for this session, toggled with F3
+func (m Model) debugView() string {
+	if m.LLMClient == nil || !m.LLMClient.Enabled {
+		return m.Styles.Debug.Render("LLM debug: narrator disabled")
+	}
+	return m.Styles.Debug.Render("LLM debug\n" + usageSummary(m.LLMClient.Usage()))
+}
+
+// usageSummary describes session usage in a few lines
+func usageSummary(u llm.SessionUsage) string {
+	budget := "no limit"
+	if u.Budget > 0 {
+		budget = fmt.Sprintf("%d of %d used", u.Tokens.Total(), u.Budget)
+		if u.BudgetSpent() {
+			budget += ", now offline"
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("Model:    %s", u.Model),
+		fmt.Sprintf("Calls:    %d (%d offline)", u.Calls, u.Offline),
+		fmt.Sprintf("Tokens:   %d prompt + %d response = %d", u.Tokens.PromptTokens, u.Tokens.ResponseTokens, u.Tokens.Total()),
+		fmt.Sprintf("Budget:   %s", budget),
+		fmt.Sprintf("Latency:  %s average", u.AverageLatency().Round(time.Millisecond)),
+		fmt.Sprintf("Cost:     ~$%.4f", u.Cost),
+	}
+	return strings.Join(lines, "\n")
+}
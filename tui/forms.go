@@ -1,13 +1,10 @@
 package tui
 
 import (
-	"fmt"
-	"strings"
-
 	"blackoutbargain/game"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // FormSubmittedMsg is sent when a form is submitted
@@ -15,112 +12,45 @@ type FormSubmittedMsg struct {
 	Value string // The submitted value
 }
 
-// CodeInputForm creates a huh form for entering codes
-func CodeInputForm(prompt string, width int) (formModel, tea.Cmd) {
-	var value string
-
-	// Create the form
-	form := huh.NewForm(
-		huh.NewGroup(
-			huh.NewInput().
-				Title(prompt).
-				Value(&value).
-				Placeholder("Enter code..."),
-		),
-	)
-
-	// Set form styles
-	form = form.WithShowHelp(false).WithWidth(width / 2)
-
-	// Create and return the model
-	model := formModel{
-		form:  form,
-		value: &value,
+// NewLockWidget creates the input widget for the code the game is waiting for:
+// combination dials for the locker and safe, a keypad for the breaker panel
+func NewLockWidget(gs *game.GameState, styles Styles) tea.Model {
+	switch gs.InputRequired {
+	case "locker_code":
+		return NewCombination("Set the combination on Dale's locker:", 7, styles)
+	case "safe_code":
+		return NewCombination("Turn the dials on the safe:", 4, styles)
+	case "breaker_code":
+		return NewKeypad("Enter the breaker activation code:", styles)
+	default:
+		return NewKeypad("Enter code:", styles)
 	}
-
-	return model, model.Init()
-}
-
-// formModel is a wrapper model for the huh form
-type formModel struct {
-	form  *huh.Form
-	value *string
-	err   error
-}
-
-func (m formModel) Init() tea.Cmd {
-	return m.form.Init()
 }
 
-func (m formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
-
-	// Handle quit for Esc key
-	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "esc" {
-		return m, tea.Quit
-	}
-
-	// Update the form
-	form, cmd := m.form.Update(msg)
-	m.form = form.(*huh.Form)
-	if cmd != nil {
-		cmds = append(cmds, cmd)
-	}
-
-	// Check if form is submitted (when form submission is done)
-	if form, ok := form.(*huh.Form); ok && form.State == huh.StateCompleted {
-		cmds = append(cmds, func() tea.Msg {
-			return FormSubmittedMsg{Value: *m.value}
-		})
-	}
+// formFrame is the border drawn around the active form
+var formFrame = lipgloss.NewStyle().
+	Padding(1, 2).
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("69"))
 
-	return m, tea.Batch(cmds...)
-}
-
-func (m formModel) View() string {
-	if m.err != nil {
-		return fmt.Sprintf("Error: %v", m.err)
-	}
-	return m.form.View()
+// formHeader renders everything above the form box
+func (m Model) formHeader() string {
+	title := m.Styles.Title.Render("--- Blackout Bargain ---")
+	return lipgloss.PlaceHorizontal(m.Width, lipgloss.Center, title) + "\n\n" +
+		m.Styles.Location.Width(m.Width).Render(m.GameState.GetLocationDescription()) + "\n\n"
 }
 
-// UpdateModelWithHuhForm integrates huh form into the main model
-func UpdateModelWithHuhForm(m *Model, msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case FormSubmittedMsg:
-		// When form is submitted, process the input code
-		input := strings.TrimSpace(msg.Value)
-
-		// Process the code according to what is required
-		m.GameState.CurrentInput = "" // Reset current input
-		m.GameState.HandleCommand(input)
-
-		// Return to normal mode
-		return *m, nil
-	}
-
-	// Handle any other form-related messages
-	return *m, nil
+// formOrigin returns the screen position of the active form's top-left
+// corner, used to translate mouse events into widget coordinates
+func (m Model) formOrigin() (x, y int) {
+	box := formFrame.Render(m.ActiveForm.View())
+	x = (m.Width-lipgloss.Width(box))/2 + formFrame.GetBorderLeftSize() + formFrame.GetPaddingLeft()
+	y = lipgloss.Height(m.formHeader()) - 1 + formFrame.GetBorderTopSize() + formFrame.GetPaddingTop()
+	return max(x, 0), y
 }
 
-// CreateCodeInputForm creates the appropriate huh form based on game state
-func CreateCodeInputForm(gs *game.GameState, width int) tea.Cmd {
-	var prompt string
-
-	switch gs.InputRequired {
-	case "locker_code":
-		prompt = "Enter the locker code:"
-	case "safe_code":
-		prompt = "Enter the safe code:"
-	case "breaker_code":
-		prompt = "Enter the breaker activation code:"
-	default:
-		prompt = "Enter code:"
-	}
-
-	return func() tea.Msg {
-		// Create a new form model
-		model, _ := CodeInputForm(prompt, width)
-		return model // Return the model directly as a message
-	}
+// openLockWidget shows the widget for the code the game is waiting for
+func (m *Model) openLockWidget() {
+	m.ActiveForm = NewLockWidget(m.GameState, m.Styles)
+	m.ShowingForm = true
 }
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// --- Command History ---
+
+// maxHistory caps how many commands are kept for browsing
+const maxHistory = 500
+
+// History is the list of commands the player has entered, browsable with
+// up/down and persisted across sessions when a file is set
+type History struct {
+	Entries []string
+	Path    string // File commands are appended to; empty keeps history in memory
+
+	index int    // Position while browsing; len(Entries) means "not browsing"
+	draft string // What the player was typing before browsing started
+	saved int    // Commands in the file, which is trimmed when it grows too long
+}
+
+// DefaultHistoryPath returns the history file under the user's config directory
+func DefaultHistoryPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "blackoutbargain", "history")
+}
+
+// LoadHistory reads previous commands from path. A missing file is not an error.
+func LoadHistory(path string) (*History, error) {
+	h := &History{Path: path}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			h.Entries = append(h.Entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		h.index = len(h.Entries)
+		return h, err
+	}
+	h.saved = len(h.Entries)
+	if len(h.Entries) > maxHistory {
+		h.Entries = h.Entries[len(h.Entries)-maxHistory:]
+	}
+	h.index = len(h.Entries)
+	if h.saved > maxHistory {
+		return h, h.rewrite()
+	}
+	return h, nil
+}
+
+// Add records a command, skipping immediate repeats, and appends it to the file
+func (h *History) Add(command string) {
+	command = strings.TrimSpace(command)
+	defer h.reset()
+	if command == "" || (len(h.Entries) > 0 && h.Entries[len(h.Entries)-1] == command) {
+		return
+	}
+	h.Entries = append(h.Entries, command)
+	if len(h.Entries) > maxHistory {
+		h.Entries = h.Entries[len(h.Entries)-maxHistory:]
+	}
+
+	if h.Path == "" {
+		return
+	}
+	// Let the file run past the cap for a while before trimming it, so
+	// most commands are a cheap append
+	var err error
+	if h.saved >= 2*maxHistory {
+		err = h.rewrite()
+	} else {
+		err = h.append(command)
+	}
+	if err != nil {
+		log.Printf("Error saving command history: %v", err)
+	}
+}
+
+// Prev steps back through history. current is what is typed right now, kept
+// so that stepping forward past the newest entry restores it.
+func (h *History) Prev(current string) (string, bool) {
+	if h.index == 0 || len(h.Entries) == 0 {
+		return current, false
+	}
+	if h.index >= len(h.Entries) {
+		h.draft = current
+		h.index = len(h.Entries)
+	}
+	h.index--
+	return h.Entries[h.index], true
+}
+
+// Next steps forward through history, ending at the saved draft
+func (h *History) Next() (string, bool) {
+	if h.index >= len(h.Entries) {
+		return "", false
+	}
+	h.index++
+	if h.index == len(h.Entries) {
+		return h.draft, true
+	}
+	return h.Entries[h.index], true
+}
+
+// reset stops browsing so the next Prev starts from the newest entry
+func (h *History) reset() {
+	h.index = len(h.Entries)
+	h.draft = ""
+}
+
+// append writes a single command to the end of the history file
+func (h *History) append(command string) error {
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = file.WriteString(command + "\n"); err != nil {
+		return err
+	}
+	h.saved++
+	return nil
+}
+
+// rewrite replaces the history file with the entries kept in memory
+func (h *History) rewrite() error {
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0755); err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for _, entry := range h.Entries {
+		sb.WriteString(entry + "\n")
+	}
+	if err := os.WriteFile(h.Path, []byte(sb.String()), 0644); err != nil {
+		return err
+	}
+	h.saved = len(h.Entries)
+	return nil
+}
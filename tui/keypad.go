@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Phone-Style Keypad Widget ---
+
+// keypadKey is a single button on the keypad
+type keypadKey struct {
+	Digit   string
+	Letters string
+}
+
+// keypadKeys is the 3x4 layout of a phone keypad
+var keypadKeys = [4][3]keypadKey{
+	{{"1", ""}, {"2", "ABC"}, {"3", "DEF"}},
+	{{"4", "GHI"}, {"5", "JKL"}, {"6", "MNO"}},
+	{{"7", "PQRS"}, {"8", "TUV"}, {"9", "WXYZ"}},
+	{{"*", "CLR"}, {"0", ""}, {"#", "OK"}},
+}
+
+const (
+	keypadKeyWidth  = 7 // Button width including its border
+	keypadKeyHeight = 4 // Button height including its border
+	keypadHeader    = 3 // Lines above the buttons: title, entered code, blank
+)
+
+// Keypad is a Bubble Tea component that renders a phone keypad. Keys are
+// pressed with the arrow keys and Enter, by typing digits (letters are
+// translated to their digit) or by clicking. '*' clears and '#' submits,
+// which sends a FormSubmittedMsg with the entered digits.
+type Keypad struct {
+	Title   string
+	Entered string
+	Styles  Styles
+
+	row, col int // Highlighted key
+}
+
+// NewKeypad creates a keypad with the "5" key highlighted
+func NewKeypad(title string, styles Styles) Keypad {
+	return Keypad{Title: title, Styles: styles, row: 1, col: 1}
+}
+
+func (k Keypad) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses and mouse clicks. Mouse coordinates must be
+// relative to the top-left corner of the keypad's View.
+func (k Keypad) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyUp:
+			k.row = max(k.row-1, 0)
+		case tea.KeyDown:
+			k.row = min(k.row+1, len(keypadKeys)-1)
+		case tea.KeyLeft:
+			k.col = max(k.col-1, 0)
+		case tea.KeyRight:
+			k.col = min(k.col+1, len(keypadKeys[0])-1)
+		case tea.KeyEnter, tea.KeySpace:
+			return k.press(keypadKeys[k.row][k.col].Digit)
+		case tea.KeyBackspace:
+			if len(k.Entered) > 0 {
+				k.Entered = k.Entered[:len(k.Entered)-1]
+			}
+		case tea.KeyRunes:
+			var cmd tea.Cmd
+			for _, r := range msg.Runes {
+				var model tea.Model
+				model, cmd = k.press(keypadDigit(r))
+				k = model.(Keypad)
+			}
+			return k, cmd
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+			return k, nil
+		}
+		row := (msg.Y - keypadHeader) / keypadKeyHeight
+		col := msg.X / keypadKeyWidth
+		if msg.Y < keypadHeader || msg.X < 0 || row >= len(keypadKeys) || col >= len(keypadKeys[0]) {
+			return k, nil
+		}
+		k.row, k.col = row, col
+		return k.press(keypadKeys[row][col].Digit)
+	}
+	return k, nil
+}
+
+// press applies a single keypad button
+func (k Keypad) press(digit string) (tea.Model, tea.Cmd) {
+	switch digit {
+	case "":
+		return k, nil
+	case "*":
+		k.Entered = ""
+		return k, nil
+	case "#":
+		value := k.Entered
+		return k, func() tea.Msg { return FormSubmittedMsg{Value: value} }
+	}
+	k.Entered += digit
+	return k, nil
+}
+
+// keypadDigit translates a typed character into the button that carries it,
+// so typing a word enters its phone-keypad digits
+func keypadDigit(r rune) string {
+	upper := strings.ToUpper(string(r))
+	for _, row := range keypadKeys {
+		for _, key := range row {
+			if key.Digit == upper || (key.Letters != "CLR" && key.Letters != "OK" && strings.Contains(key.Letters, upper)) {
+				return key.Digit
+			}
+		}
+	}
+	return ""
+}
+
+func (k Keypad) View() string {
+	var rows []string
+	for r, row := range keypadKeys {
+		var keys []string
+		for c, key := range row {
+			style := k.Styles.KeypadKey
+			if r == k.row && c == k.col {
+				style = k.Styles.KeypadKeySelected
+			}
+			keys = append(keys, style.Render(key.Digit+"\n"+key.Letters))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, keys...))
+	}
+
+	entered := k.Entered
+	if entered == "" {
+		entered = "_"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(k.Styles.Prompt.Render(k.Title))
+	sb.WriteString("\n")
+	sb.WriteString(k.Styles.KeypadDisplay.Render(entered))
+	sb.WriteString("\n\n")
+	sb.WriteString(lipgloss.JoinVertical(lipgloss.Left, rows...))
+	sb.WriteString("\n\n")
+	sb.WriteString(k.Styles.Help.Render("Arrows + Enter or click to press, type digits or letters\n* clears, # submits, Backspace deletes"))
+	return sb.String()
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"strings"
+
+	"blackoutbargain/game"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- ASCII Map Panel ---
+
+const (
+	mapRoomWidth  = 12 // Box width including borders
+	mapRoomHeight = 3  // Box height including borders
+	mapWidth      = 40
+	mapHeight     = 11
+)
+
+// mapRoom places a location on the map canvas
+type mapRoom struct {
+	X, Y  int
+	Width int
+	Label string
+}
+
+// mapRooms lays out the store. Rooms that share an exit must overlap either
+// horizontally (stacked) or vertically (side by side) so a connector can be drawn.
+var mapRooms = map[game.Location]mapRoom{
+	game.LocLoadingDock:     {X: 3, Y: 0, Width: 22, Label: "DOCK"},
+	game.LocManagersOffice:  {X: 0, Y: 4, Width: mapRoomWidth, Label: "OFFICE"},
+	game.LocSecurityStation: {X: 14, Y: 4, Width: mapRoomWidth, Label: "SECURITY"},
+	game.LocLockerArea:      {X: 28, Y: 4, Width: mapRoomWidth, Label: "LOCKERS"},
+	game.LocRegister:        {X: 14, Y: 8, Width: mapRoomWidth, Label: "REGISTER"},
+}
+
+// mapCellKind selects the style a map cell is rendered with
+type mapCellKind int
+
+const (
+	cellFog mapCellKind = iota
+	cellKnown
+	cellVisited
+	cellCurrent
+)
+
+type mapCell struct {
+	r    rune
+	kind mapCellKind
+}
+
+// mapCanvas is a fixed-size grid of styled runes
+type mapCanvas [mapHeight][mapWidth]mapCell
+
+func (c *mapCanvas) set(x, y int, r rune, kind mapCellKind) {
+	if y < 0 || y >= mapHeight || x < 0 || x >= mapWidth {
+		return
+	}
+	c[y][x] = mapCell{r: r, kind: kind}
+}
+
+// RenderMap draws the store map with fog of war: the current room is
+// highlighted, visited rooms are drawn normally, rooms the player knows about
+// but hasn't entered are dimmed and everything else stays hidden.
+func RenderMap(gs *game.GameState, styles Styles) string {
+	var canvas mapCanvas
+	for y := range canvas {
+		for x := range canvas[y] {
+			canvas[y][x] = mapCell{r: ' ', kind: cellFog}
+		}
+	}
+
+	kindOf := func(loc game.Location) mapCellKind {
+		switch {
+		case gs.Location == loc:
+			return cellCurrent
+		case gs.HasVisited(loc):
+			return cellVisited
+		case gs.IsKnown(loc):
+			return cellKnown
+		default:
+			return cellFog
+		}
+	}
+
+	// Connectors first so room borders are drawn over their ends
+	for _, exit := range game.Exits {
+		from, okFrom := mapRooms[exit.From]
+		to, okTo := mapRooms[exit.To]
+		if !okFrom || !okTo || kindOf(exit.From) == cellFog || kindOf(exit.To) == cellFog {
+			continue
+		}
+		kind := min(kindOf(exit.From), kindOf(exit.To), cellVisited)
+		drawConnector(&canvas, from, to, kind)
+	}
+
+	for _, loc := range game.Locations {
+		room, ok := mapRooms[loc]
+		if !ok || kindOf(loc) == cellFog {
+			continue
+		}
+		label := room.Label
+		switch kindOf(loc) {
+		case cellCurrent:
+			label = "@ " + label
+		case cellKnown:
+			label += "?"
+		}
+		drawRoom(&canvas, room, label, kindOf(loc))
+	}
+
+	return canvas.render(styles)
+}
+
+// drawRoom draws a bordered box with a centered label
+func drawRoom(c *mapCanvas, room mapRoom, label string, kind mapCellKind) {
+	right := room.X + room.Width - 1
+	bottom := room.Y + mapRoomHeight - 1
+	for x := room.X; x <= right; x++ {
+		c.set(x, room.Y, '-', kind)
+		c.set(x, bottom, '-', kind)
+	}
+	for y := room.Y; y <= bottom; y++ {
+		c.set(room.X, y, '|', kind)
+		c.set(right, y, '|', kind)
+	}
+	for _, corner := range [][2]int{{room.X, room.Y}, {right, room.Y}, {room.X, bottom}, {right, bottom}} {
+		c.set(corner[0], corner[1], '+', kind)
+	}
+	for x := room.X + 1; x < right; x++ {
+		c.set(x, room.Y+1, ' ', kind)
+	}
+
+	inner := room.Width - 2
+	runes := []rune(label)
+	if len(runes) > inner {
+		runes = runes[:inner]
+	}
+	start := room.X + 1 + (inner-len(runes))/2
+	for i, r := range runes {
+		c.set(start+i, room.Y+1, r, kind)
+	}
+}
+
+// drawConnector joins two rooms with a straight line through their overlap
+func drawConnector(c *mapCanvas, a, b mapRoom, kind mapCellKind) {
+	// Side by side: horizontal line through the shared rows
+	if a.Y == b.Y {
+		if a.X > b.X {
+			a, b = b, a
+		}
+		y := a.Y + mapRoomHeight/2
+		for x := a.X + a.Width; x < b.X; x++ {
+			c.set(x, y, '-', kind)
+		}
+		return
+	}
+
+	// Stacked: vertical line through the middle of the shared columns
+	if a.Y > b.Y {
+		a, b = b, a
+	}
+	left := max(a.X, b.X)
+	right := min(a.X+a.Width, b.X+b.Width) - 1
+	if left > right {
+		return
+	}
+	x := (left + right) / 2
+	for y := a.Y + mapRoomHeight; y < b.Y; y++ {
+		c.set(x, y, '|', kind)
+	}
+}
+
+// render converts the canvas to styled text, one style run at a time
+func (c *mapCanvas) render(styles Styles) string {
+	styleFor := map[mapCellKind]lipgloss.Style{
+		cellFog:     lipgloss.NewStyle(),
+		cellKnown:   styles.MapKnown,
+		cellVisited: styles.MapVisited,
+		cellCurrent: styles.MapCurrent,
+	}
+
+	lines := make([]string, 0, mapHeight)
+	for y := range c {
+		var line strings.Builder
+		var run strings.Builder
+		runKind := c[y][0].kind
+		for x := range c[y] {
+			cell := c[y][x]
+			if cell.kind != runKind {
+				line.WriteString(styleFor[runKind].Render(run.String()))
+				run.Reset()
+				runKind = cell.kind
+			}
+			run.WriteRune(cell.r)
+		}
+		line.WriteString(styleFor[runKind].Render(run.String()))
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
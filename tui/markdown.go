@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// --- Narrator Markdown ---
+
+// narration renders the narrator's Markdown. Building a renderer and running
+// it are slow, so it keeps one renderer and every entry's output for the
+// current wrap width.
+type narration struct {
+	mu       sync.Mutex
+	style    ansi.StyleConfig
+	width    int
+	renderer *glamour.TermRenderer
+	rendered map[string]string // Narrator text to its rendering at width
+}
+
+// newNarration picks the Markdown style for the terminal. Call it before the
+// program starts: checking the background color queries the terminal, which
+// would fight Bubble Tea for the input once it is running.
+func newNarration() *narration {
+	return &narration{style: narrationStyle()}
+}
+
+// renderNarration renders the narrator's Markdown wrapped to width. Text
+// that can't be rendered is shown as it came.
+func (m Model) renderNarration(text string, width int) string {
+	if m.narration == nil {
+		return m.Styles.Narrator.Width(width).Render(text)
+	}
+	out, err := m.narration.render(text, width)
+	if err != nil {
+		log.Printf("Error rendering narration: %v", err)
+		return m.Styles.Narrator.Width(width).Render(text)
+	}
+	return out
+}
+
+// render returns the rendering of text at width, reusing earlier output
+func (n *narration) render(text string, width int) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.renderer == nil || n.width != width {
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithStyles(n.style),
+			glamour.WithColorProfile(lipgloss.ColorProfile()),
+			glamour.WithWordWrap(width),
+		)
+		if err != nil {
+			return "", err
+		}
+		n.renderer = renderer
+		n.width = width
+		n.rendered = map[string]string{}
+	}
+
+	if out, ok := n.rendered[text]; ok {
+		return out, nil
+	}
+	out, err := n.renderer.Render(text)
+	if err != nil {
+		return "", err
+	}
+	out = strings.Trim(out, "\n")
+	n.rendered[text] = out
+	return out, nil
+}
+
+// narrationStyle is glamour's standard style without the document margins,
+// in the narrator's pale yellow. Plain terminals get the ASCII style.
+func narrationStyle() ansi.StyleConfig {
+	style := styles.DarkStyleConfig
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		style = styles.ASCIIStyleConfig
+	} else if !lipgloss.HasDarkBackground() {
+		style = styles.LightStyleConfig
+	}
+
+	noMargin := uint(0)
+	narratorColor := "187"
+	style.Document.Margin = &noMargin
+	style.Document.BlockPrefix = ""
+	style.Document.BlockSuffix = ""
+	style.Document.Color = &narratorColor
+	return style
+}
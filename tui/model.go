@@ -1,12 +1,17 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"blackoutbargain/game"
 	"blackoutbargain/llm"
+	"blackoutbargain/profile"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -26,60 +31,134 @@ type Model struct {
 	ActiveForm  tea.Model // Currently active form, if any
 	ShowingForm bool      // Flag to indicate if a form is active
 
+	// Scoring state
+	HighScores    *profile.HighScoreTable // Local leaderboard
+	HighScorePath string                  // Where the leaderboard is saved; empty keeps it in memory
+	ScoreRank     int                     // Rank of this game in the leaderboard, 0 if unranked
+
+	// Achievement state
+	Achievements     *profile.Achievements // Progress kept across playthroughs
+	AchievementsPath string                // Where progress is saved; empty keeps it in memory
+	Toasts           []Toast               // Notifications currently on screen
+	nextToastID      int
+
+	// Title screen state
+	ShowingTitle        bool // Flag to indicate the title menu is shown before play
+	ShowingAchievements bool // Flag to indicate the achievements list is open
+	TitleCursor         int  // Selected title menu option
+
+	// Debug state
+	ShowingDebug bool // Flag to indicate the LLM usage overlay is shown
+
+	// Clue board state
+	ShowingBoard bool // Flag to indicate the clue board is open
+	BoardCursor  int  // Selected clue on the board
+
+	// Input state
+	Input   textinput.Model // Line editor for commands
+	History *History        // Previously entered commands
+
+	// Transcript state
+	Transcript       []TranscriptEntry // Every command and response so far
+	Viewport         viewport.Model    // Scrollable view over the transcript
+	FollowTranscript bool              // Keep the newest entry in view
+	narration        *narration        // Renders narrator Markdown
+
 	// LLM state
 	LLMClient    *llm.Client
-	LastLLMInput string // Store the input that triggered the LLM call
+	LastLLMInput string             // Store the input that triggered the LLM call
+	cancelLLM    context.CancelFunc // Stops the call in flight
+	llmRequest   int                // ID of the call in flight; replies to older calls are dropped
 }
 
 // New creates a new TUI model
 func New(llmClient *llm.Client) Model {
 	return Model{
-		GameState:  game.NewGameState(),
-		Styles:     NewStyles(),
-		LLMClient:  llmClient,
-		LoadingLLM: false,
+		GameState:        game.NewGameState(),
+		Input:            newInput(),
+		History:          &History{},
+		HighScores:       &profile.HighScoreTable{},
+		Achievements:     profile.NewAchievements(),
+		ShowingTitle:     true,
+		Styles:           NewStyles(),
+		LLMClient:        llmClient,
+		LoadingLLM:       false,
+		Viewport:         viewport.New(0, 0),
+		FollowTranscript: true,
+		narration:        newNarration(),
 	}
 }
 
+// newInput creates the command line editor. Up/down are reserved for
+// history, so suggestions are cycled with ctrl+n/ctrl+p and accepted with tab.
+func newInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.ShowSuggestions = true
+	ti.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	ti.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+	ti.Focus()
+	return ti
+}
+
 // --- Bubble Tea Messages ---
 
 // LLMResponseMsg is used for receiving LLM responses
 type LLMResponseMsg struct {
-	Response string
-	Err      error
+	RequestID int // Which call this answers
+	Response  string
+	Err       error
 }
 
 // LLMErrorMsg is used for handling specific LLM API call errors
 type LLMErrorMsg struct {
-	Err error
+	RequestID int
+	Err       error
 }
 
 // --- Bubble Tea Interface Implementation ---
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	// No initial command needed for now
-	return nil
+	return textinput.Blink // Start the cursor blinking
 }
 
 // Update handles input and events
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd // Collect multiple commands
 
+	// Toasts expire on their own timers, whatever screen is showing
+	if msg, ok := msg.(toastExpiredMsg); ok {
+		m.dismissToast(msg.ID)
+		return m, nil
+	}
+
+	// The title screen takes over the keyboard until a game is started
+	if msg, ok := msg.(tea.KeyMsg); ok && m.ShowingTitle {
+		return m.updateTitle(msg)
+	}
+
+	// The clue board takes over the keyboard while it is open
+	if msg, ok := msg.(tea.KeyMsg); ok && m.ShowingBoard {
+		return m.updateBoard(msg)
+	}
+
+	// PgUp/PgDn and the mouse wheel scroll the transcript outside of forms
+	if !m.ShowingForm && !m.ShowingBoard {
+		if handled, cmd := m.scrollTranscript(msg); handled {
+			return m, cmd
+		}
+	}
+
 	// Handle form-related updates if a form is active
 	if m.ShowingForm && m.ActiveForm != nil {
 		switch msg := msg.(type) {
-		case formModel:
-			// Initialize a new form
-			m.ActiveForm = msg
-			m.ShowingForm = true
-			return m, nil
-
 		case tea.KeyMsg:
 			if msg.String() == "esc" {
-				// Cancel form on escape
+				// Cancel form on escape and step away from the lock
 				m.ActiveForm = nil
 				m.ShowingForm = false
+				m.addResult(m.GameState.CancelInput())
 				return m, nil
 			}
 
@@ -88,13 +167,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ActiveForm = newForm
 			return m, cmd
 
+		case tea.MouseMsg:
+			// Translate to coordinates relative to the form
+			x, y := m.formOrigin()
+			msg.X -= x
+			msg.Y -= y
+			newForm, cmd := m.ActiveForm.Update(msg)
+			m.ActiveForm = newForm
+			return m, cmd
+
 		case FormSubmittedMsg:
 			// Handle form submission
 			input := strings.TrimSpace(msg.Value)
-			m.GameState.HandleCommand(input)
 			m.ActiveForm = nil
 			m.ShowingForm = false
-			return m, nil
+			return m, m.runCommand(input)
 		}
 
 		// For any other message type, try updating the form
@@ -112,31 +199,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// --- Handle LLM Response ---
 	case LLMResponseMsg:
-		m.LoadingLLM = false // Turn off loading indicator
-		m.LastLLMInput = ""  // Clear context
+		if !m.LoadingLLM || msg.RequestID != m.llmRequest {
+			return m, nil // The player stopped waiting for it
+		}
+		m.finishLLM()
 		if msg.Err != nil {
 			// This case might be less common if errors are caught by LLMErrorMsg
-			m.GameState.Message = fmt.Sprintf("LLM Response Error: %s", msg.Err)
+			m.addEntry(EntryEngine, fmt.Sprintf("LLM Response Error: %s", msg.Err))
 		} else {
-			m.GameState.Message = msg.Response
+			m.addEntry(EntryNarrator, msg.Response)
 			// Optional: Parse LLM response for specific clues recognized by Go game state
 			// e.g., if strings.Contains(strings.ToLower(msg.response), "code 4711") { m.GameState.Clues["safe_code_hint"] = "4711" }
 		}
 		return m, nil // No further command needed now
 
 	case LLMErrorMsg:
-		m.LoadingLLM = false
-		m.LastLLMInput = ""
-		m.GameState.Message = fmt.Sprintf("LLM API Error: %s", msg.Err) // Display the specific error
+		if !m.LoadingLLM || msg.RequestID != m.llmRequest {
+			return m, nil
+		}
+		m.finishLLM()
+		m.addEntry(EntryEngine, fmt.Sprintf("LLM API Error: %s", msg.Err)) // Display the specific error
 		return m, nil
 
 	case tea.KeyMsg:
+		// The debug overlay can be toggled at any time, even mid-call
+		if msg.Type == tea.KeyF3 {
+			m.ShowingDebug = !m.ShowingDebug
+			return m, nil
+		}
+
 		// --- Handle Input While LLM Loading ---
 		if m.LoadingLLM {
 			// Allow quitting even while loading
-			if msg.Type == tea.KeyCtrlC || msg.Type == tea.KeyEsc {
+			if msg.Type == tea.KeyCtrlC {
 				return m, tea.Quit
 			}
+			// Esc gives up on the narrator and goes back to the prompt
+			if msg.Type == tea.KeyEsc {
+				m.finishLLM()
+				m.addEntry(EntryEngine, "You stop waiting for the narrator.")
+				return m, nil
+			}
 			// Option: Display a message like "Please wait..."
 			// Or simply ignore other keys
 			return m, nil
@@ -147,94 +250,74 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return m, tea.Quit
 
+		case tea.KeyF2:
+			m.ShowingBoard = true
+			return m, nil
+
 		case tea.KeyEnter:
-			input := strings.TrimSpace(m.GameState.CurrentInput)
-			m.GameState.CurrentInput = "" // Reset input field
-			m.GameState.Message = ""      // Clear previous message (LLM or Go)
+			input := strings.TrimSpace(m.Input.Value())
+			m.Input.Reset()      // Reset input field
+			m.History.Add(input) // Remember it for up/down
 
 			if input == "" {
-				m.GameState.Message = "Please enter a command."
+				m.addEntry(EntryEngine, "Please enter a command.")
 				return m, nil
 			}
+			m.addEntry(EntryInput, input)
 
 			// --- Input Routing: Go Logic vs. LLM ---
 			if m.GameState.InputRequired != "" {
-				// Show form for code input
-				m.ShowingForm = true
-				return m, CreateCodeInputForm(m.GameState, m.Width)
-			} else {
-				// --- Delegate general actions based on verb ---
-				parts := strings.Fields(strings.ToLower(input))
-				verb := ""
-				if len(parts) > 0 {
-					verb = parts[0]
-				}
+				// Show the lock widget for code input
+				m.openLockWidget()
+				return m, nil
+			}
 
-				switch verb {
-				case "go", "g", "take", "t", "inventory", "i", "inv", "help", "h", "escape":
-					// Handle these navigation/core actions directly with Go logic
-					m.GameState.HandleCommand(input)
-					return m, nil
-
-				case "use", "u":
-					// Use Go for critical puzzle items/codes, delegate others to LLM
-					if m.GameState.IsCriticalUse(input) {
-						m.GameState.HandleCommand(input) // Use Go logic
-						return m, nil
-					} else {
-						// Delegate non-critical 'use' to LLM
-						if m.LLMClient == nil || !m.LLMClient.Enabled {
-							m.GameState.Message = "LLM is disabled. Cannot process this 'use' command flexibly."
-							return m, nil
-						} else {
-							m.LoadingLLM = true
-							m.LastLLMInput = input              // Store for loading message
-							m.GameState.Message = "Thinking..." // Placeholder message
-							return m, m.callLLM(input)
-						}
-					}
-
-				case "examine", "x", "look", "l", "talk", "ask", "search": // Common verbs for LLM
-					// Delegate descriptive/interactive actions to LLM
-					if m.LLMClient == nil || !m.LLMClient.Enabled {
-						// Fallback Go logic if LLM disabled
-						m.GameState.HandleCommand(input)
-						return m, nil
-					} else {
-						m.LoadingLLM = true
-						m.LastLLMInput = input
-						m.GameState.Message = "Thinking..."
-						return m, m.callLLM(input)
-					}
-				default:
-					// Handle unknown verbs - delegate to LLM if available
-					if m.LLMClient == nil || !m.LLMClient.Enabled {
-						m.GameState.Message = fmt.Sprintf("I don't understand '%s'. Try 'help'.", input)
-						return m, nil
-					} else {
-						m.LoadingLLM = true
-						m.LastLLMInput = input
-						m.GameState.Message = "Thinking..."
-						return m, m.callLLM(input)
-					}
+			switch m.GameState.RouteCommand(input) {
+			case game.RouteNotebook:
+				// Open the clue board instead of running a game command
+				m.ShowingBoard = true
+				return m, nil
+
+			case game.RouteNarrator:
+				if m.LLMClient != nil && m.LLMClient.Enabled {
+					return m, m.startLLM(input)
 				}
+				// Fallback Go logic if LLM disabled
+				return m, m.runCommand(input)
+
+			default:
+				// Navigation, puzzle codes and core actions use Go logic
+				return m, m.runCommand(input)
 			}
 
-		case tea.KeyBackspace:
-			if len(m.GameState.CurrentInput) > 0 {
-				// Handle UTF-8 runes correctly if needed, but simple slice is ok for basic input
-				m.GameState.CurrentInput = m.GameState.CurrentInput[:len(m.GameState.CurrentInput)-1]
+		case tea.KeyUp:
+			if previous, ok := m.History.Prev(m.Input.Value()); ok {
+				m.Input.SetValue(previous)
+				m.Input.CursorEnd()
 			}
 			return m, nil
 
-		// Append typed characters to the input buffer
-		default:
-			// Check if the key press represents a printable character
-			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
-				m.GameState.CurrentInput += string(msg.Runes)
-				return m, nil
+		case tea.KeyDown:
+			if next, ok := m.History.Next(); ok {
+				m.Input.SetValue(next)
+				m.Input.CursorEnd()
 			}
+			return m, nil
 		}
+
+		// Everything else edits the line: typing, cursor movement,
+		// word deletion and tab completion
+		m.Input.SetSuggestions(m.GameState.Completions())
+		var cmd tea.Cmd
+		m.Input, cmd = m.Input.Update(msg)
+		return m, cmd
+	}
+
+	// Keep the cursor blinking
+	if !m.LoadingLLM {
+		var cmd tea.Cmd
+		m.Input, cmd = m.Input.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	// Return the updated model and any commands generated by non-key messages
@@ -244,43 +327,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the TUI
 func (m Model) View() string {
 	if m.GameState.GameOver {
-		// Use Lip Gloss for final message too
-		finalMsg := "You shove the heavy door open and slip out into the fierce storm. Sirens approach...\n\nYou escaped the Blackout Nightmare!"
-		help := "\n\nPress Ctrl+C or Esc to exit."
-		return m.Styles.Base.Render(m.Styles.Message.Render(finalMsg)+m.Styles.Help.Render(help)) + "\n"
+		return m.reportView()
 	}
 
 	if m.Width == 0 {
 		return "Initializing terminal size..." // Avoid rendering before we have dimensions
 	}
 
+	if m.ShowingTitle {
+		return m.titleView()
+	}
+
+	// The clue board replaces the game screen while open
+	if m.ShowingBoard {
+		return m.boardView()
+	}
+
 	// If a form is active, render it within our UI
 	if m.ShowingForm && m.ActiveForm != nil {
 		var s strings.Builder
 
-		// Title
-		title := m.Styles.Title.Render("--- Blackout Bargain ---")
-		s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Center, title))
-		s.WriteString("\n\n")
-
-		// Game context
-		s.WriteString(m.Styles.Location.Render(m.GameState.GetLocationDescription()))
-		s.WriteString("\n\n")
+		// Title and game context
+		s.WriteString(m.formHeader())
 
 		// Form
-		formView := m.ActiveForm.View()
-		styledForm := lipgloss.NewStyle().
-			Padding(1, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("69")).
-			Render(formView)
-
+		styledForm := formFrame.Render(m.ActiveForm.View())
 		s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Center, styledForm))
 		s.WriteString("\n\n")
 
 		// Footer
 		s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Left,
-			m.Styles.Help.Render("Enter to submit, Esc to cancel")))
+			m.Styles.Help.Render("Esc to step away from the lock")))
 
 		return s.String()
 	}
@@ -296,70 +373,205 @@ func (m Model) View() string {
 	// --- Main Content Area ---
 	var mainContent strings.Builder
 
-	if m.LoadingLLM {
-		// --- Loading State ---
-		loadingText := fmt.Sprintf("Processing '%s'...", m.LastLLMInput)
-		// You could add a spinner here using charm/bubbles/spinner
-		mainContent.WriteString(m.Styles.Message.Foreground(lipgloss.Color("220")).Render(loadingText)) // Yellowish message
-	} else {
-		// --- Normal Game State View ---
-		// Location Description (Generated by Go)
-		mainContent.WriteString(m.Styles.Location.Render(m.GameState.GetLocationDescription()))
-		mainContent.WriteString("\n") // Add spacing
-
-		// Visible Items (Generated by Go)
-		visibleItems := m.GameState.GetVisibleItems()
-		if visibleItems != "" {
-			mainContent.WriteString(m.Styles.Items.Render(visibleItems))
-			mainContent.WriteString("\n")
-		}
-
-		// Inventory (Generated by Go)
-		mainContent.WriteString(m.Styles.Inventory.Render(m.GameState.GetInventoryDescription()))
-		mainContent.WriteString("\n\n") // More spacing
+	// --- Status (Generated by Go) ---
+	mainContent.WriteString(m.statusView())
+	mainContent.WriteString("\n\n") // More spacing
 
-		// Message Area (Go messages or LLM response)
-		if m.GameState.Message != "" {
-			mainContent.WriteString(m.Styles.Message.Render(m.GameState.Message))
-			mainContent.WriteString("\n\n")
-		}
+	// --- Transcript (Go messages and LLM responses) ---
+	width, height := m.transcriptSize()
+	vp := m.transcriptViewport(width, height)
+	mainContent.WriteString(vp.View())
+	mainContent.WriteString("\n\n")
 
-		// Input Prompt
-		mainContent.WriteString(m.getStyledInputPrompt())
-	}
+	// --- Input Prompt or Loading State ---
+	mainContent.WriteString(m.promptView())
 
 	// Combine content and apply base padding/styling
-	// Ensure content doesn't exceed terminal height (basic wrapping)
-	styledContent := m.Styles.Base.Width(m.Width).Render(mainContent.String())
+	contentWidth, mapPanel := m.contentWidth()
+	styledContent := m.Styles.Base.Width(contentWidth).Render(mainContent.String())
+	if mapPanel != "" {
+		styledContent = lipgloss.JoinHorizontal(lipgloss.Top, styledContent, mapPanel)
+	}
 	s.WriteString(styledContent)
 
 	// --- Footer Help Text ---
 	s.WriteString("\n\n")
-	s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Left, m.Styles.Help.Render("Ctrl+C or Esc to quit.")))
+	if m.ShowingDebug {
+		s.WriteString(m.debugView())
+		s.WriteString("\n")
+	}
+	if len(m.Toasts) > 0 {
+		s.WriteString(m.toastView())
+		s.WriteString("\n")
+	}
+	s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Left, m.Styles.Help.Render("Tab completes, Up/Down recalls history, PgUp/PgDn or mouse wheel scrolls, F2 opens your notebook, F3 shows LLM usage. Ctrl+C or Esc to quit.")))
 
 	return s.String()
 }
 
-// getStyledInputPrompt returns the styled input prompt string
+// statusView renders the location, exits, visible items and inventory
+func (m Model) statusView() string {
+	var sb strings.Builder
+
+	// Location Description
+	sb.WriteString(m.Styles.Location.Render(m.GameState.GetLocationDescription()))
+
+	// Exits
+	if exits := m.GameState.GetExitsDescription(); exits != "" {
+		sb.WriteString("\n")
+		sb.WriteString(m.Styles.Exits.Render(exits))
+	}
+
+	// Visible Items
+	if visibleItems := m.GameState.GetVisibleItems(); visibleItems != "" {
+		sb.WriteString("\n")
+		sb.WriteString(m.Styles.Items.Render(visibleItems))
+	}
+
+	// Inventory
+	sb.WriteString("\n")
+	sb.WriteString(m.Styles.Inventory.Render(m.GameState.GetInventoryDescription()))
+
+	return sb.String()
+}
+
+// promptView renders the input prompt, or a loading line while the LLM works
+func (m Model) promptView() string {
+	if m.LoadingLLM {
+		loadingText := fmt.Sprintf("Processing '%s'... (Esc to stop waiting)", m.LastLLMInput)
+		// You could add a spinner here using charm/bubbles/spinner
+		return m.Styles.Message.Foreground(lipgloss.Color("220")).Render(loadingText) // Yellowish message
+	}
+	return m.getStyledInputPrompt()
+}
+
+// contentWidth returns the width of the main column and the map panel that
+// sits beside it. The panel is dropped when the terminal is too narrow.
+func (m Model) contentWidth() (int, string) {
+	mapPanel := m.mapPanel()
+	contentWidth := m.Width - lipgloss.Width(mapPanel)
+	if contentWidth < minContentWidth {
+		return m.Width, ""
+	}
+	return contentWidth, mapPanel
+}
+
+// transcriptSize returns the area left for the transcript once the title,
+// status, prompt and footer have been laid out
+func (m Model) transcriptSize() (int, int) {
+	contentWidth, _ := m.contentWidth()
+	innerWidth := contentWidth - m.Styles.Base.GetHorizontalFrameSize()
+
+	status := lipgloss.NewStyle().Width(innerWidth).Render(m.statusView())
+	prompt := lipgloss.NewStyle().Width(innerWidth).Render(m.promptView())
+	titleLines := lipgloss.Height(m.Styles.Title.Render("--- Blackout Bargain ---")) + 1
+	footerLines := 2 // Blank line plus help text
+	if m.ShowingDebug {
+		footerLines += lipgloss.Height(m.debugView())
+	}
+	if len(m.Toasts) > 0 {
+		footerLines += lipgloss.Height(m.toastView())
+	}
+	spacing := 2 // Blank lines around the transcript
+
+	height := m.Height - titleLines - lipgloss.Height(status) - lipgloss.Height(prompt) - footerLines - spacing
+	return innerWidth, max(height, minTranscriptHeight)
+}
+
+// minTranscriptHeight keeps a few transcript lines visible on tiny terminals
+const minTranscriptHeight = 3
+
+// minContentWidth is the narrowest main column worth keeping next to the map panel
+const minContentWidth = 40
+
+// mapPanel renders the bordered map side panel with a legend
+func (m Model) mapPanel() string {
+	legend := m.Styles.MapCurrent.Render("@ you") + "  " +
+		m.Styles.MapVisited.Render("visited") + "  " +
+		m.Styles.MapKnown.Render("? unexplored")
+	return m.Styles.MapPanel.Render(m.Styles.Title.Render("Map") + "\n" + RenderMap(m.GameState, m.Styles) + "\n\n" + legend)
+}
+
+// getStyledInputPrompt returns the styled input prompt with the line editor
 func (m Model) getStyledInputPrompt() string {
-	return m.Styles.Prompt.Render(m.GameState.GetInputPrompt()) + m.GameState.CurrentInput
+	prompt := "> "
+	if m.GameState.InputRequired != "" {
+		prompt = fmt.Sprintf("Enter %s: ", strings.ReplaceAll(m.GameState.InputRequired, "_", " "))
+	}
+	return m.Styles.Prompt.Render(prompt) + m.Input.View()
+}
+
+// startLLM shows the loading state and hands the command to the LLM, along
+// with a snapshot of the game as it is now so the call can't race the
+// commands that follow
+func (m *Model) startLLM(input string) tea.Cmd {
+	m.LoadingLLM = true
+	m.LastLLMInput = input // Store for loading message
+	m.GameState.RecordNarratedTurn()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLLM = cancel
+	m.llmRequest++
+	return m.callLLM(ctx, m.llmRequest, input, m.GameState.Snapshot())
+}
+
+// finishLLM leaves the loading state, stopping the call if it is still running
+func (m *Model) finishLLM() {
+	if m.cancelLLM != nil {
+		m.cancelLLM()
+		m.cancelLLM = nil
+	}
+	m.LoadingLLM = false
+	m.LastLLMInput = ""
 }
 
-// callLLM constructs a command to call the LLM service
-func (m Model) callLLM(playerInput string) tea.Cmd {
+// callLLM constructs a command to call the LLM service. It runs on its own
+// goroutine, so it must only read the snapshot it is given.
+func (m Model) callLLM(ctx context.Context, requestID int, playerInput string, snapshot *game.GameState) tea.Cmd {
+	llmClient := m.LLMClient
 	return func() tea.Msg {
-		if m.LLMClient == nil || !m.LLMClient.Enabled {
+		if llmClient == nil || !llmClient.Enabled {
 			return LLMResponseMsg{
-				Response: "LLM support is not available. Using basic descriptions.",
-				Err:      nil,
+				RequestID: requestID,
+				Response:  "LLM support is not available. Using basic descriptions.",
+				Err:       nil,
 			}
 		}
 
-		response, err := m.LLMClient.GenerateResponse(playerInput, m.GameState)
+		response, err := llmClient.GenerateResponse(ctx, playerInput, snapshot)
 		if err != nil {
-			return LLMErrorMsg{Err: err}
+			return LLMErrorMsg{RequestID: requestID, Err: err}
 		}
 
-		return LLMResponseMsg{Response: response, Err: nil}
+		return LLMResponseMsg{RequestID: requestID, Response: response, Err: nil}
+	}
+}
+
+// runCommand passes input to the Go game logic and records its reply.
+// Returns the command that shows any achievements it unlocked.
+func (m *Model) runCommand(input string) tea.Cmd {
+	result := m.GameState.HandleCommand(input)
+	m.addResult(result)
+	cmd := m.handleEvents(result.Events)
+
+	// The game is waiting for a code: bring up the lock
+	if result.Prompt != "" && !m.ShowingForm {
+		m.openLockWidget()
+	}
+	return cmd
+}
+
+// handleEvents reacts to what the last command changed in the game
+func (m *Model) handleEvents(events []game.Event) tea.Cmd {
+	if len(events) == 0 {
+		return nil
+	}
+	for _, event := range events {
+		switch event := event.(type) {
+		case game.ClueDiscovered:
+			m.noteClue(event.Clue)
+		case game.GameEnded:
+			m.recordScore()
+		}
 	}
+	return m.checkAchievements()
 }
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"blackoutbargain/game"
+	"blackoutbargain/llm"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Snapshot size, roomy enough for the map panel beside the transcript
+const (
+	snapshotWidth  = 100
+	snapshotHeight = 32
+)
+
+func TestMain(m *testing.M) {
+	// Plain text and a frozen clock keep the snapshots stable
+	lipgloss.SetColorProfile(termenv.Ascii)
+	game.Now = func() time.Time { return time.Date(2025, 1, 17, 21, 30, 0, 0, time.UTC) }
+	os.Exit(m.Run())
+}
+
+// newSnapshotModel returns a sized model past the title screen
+func newSnapshotModel(llmClient *llm.Client) tea.Model {
+	var m tea.Model = New(llmClient)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: snapshotWidth, Height: snapshotHeight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter}) // New Game
+	return m
+}
+
+// enter types a command into the line editor and submits it
+func enter(m tea.Model, command string) tea.Model {
+	for _, r := range command {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return m
+}
+
+// assertGolden compares a view with testdata/<name>.golden, rewriting the
+// file instead when -update is set
+func assertGolden(t *testing.T, name, view string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(view), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run go test ./tui -update to create it): %v", err)
+	}
+	if view != string(want) {
+		t.Errorf("View() doesn't match %s (run go test ./tui -update if the change is intended)\n--- got ---\n%s\n--- want ---\n%s", path, view, want)
+	}
+}
+
+func TestViewSnapshots(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps func() tea.Model
+	}{
+		{
+			name: "title",
+			steps: func() tea.Model {
+				var m tea.Model = New(nil)
+				m, _ = m.Update(tea.WindowSizeMsg{Width: snapshotWidth, Height: snapshotHeight})
+				return m
+			},
+		},
+		{
+			name: "start",
+			steps: func() tea.Model {
+				return newSnapshotModel(nil)
+			},
+		},
+		{
+			name: "moved",
+			steps: func() tea.Model {
+				m := newSnapshotModel(nil)
+				m = enter(m, "n")
+				return enter(m, "take crumpled employee discount voucher")
+			},
+		},
+		{
+			name: "typing",
+			steps: func() tea.Model {
+				m := enter(newSnapshotModel(nil), "n")
+				for _, r := range "take cr" {
+					m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+				}
+				return m
+			},
+		},
+		{
+			name: "lock_form",
+			steps: func() tea.Model {
+				m := newSnapshotModel(nil)
+				m = enter(m, "n")
+				m = enter(m, "e")
+				return enter(m, "use 8675309")
+			},
+		},
+		{
+			name: "lock_submitted",
+			steps: func() tea.Model {
+				m := newSnapshotModel(nil)
+				m = enter(m, "n")
+				m = enter(m, "e")
+				m = enter(m, "use 8675309")
+				m, _ = m.Update(FormSubmittedMsg{Value: "8675309"})
+				return m
+			},
+		},
+		{
+			name: "llm_loading",
+			steps: func() tea.Model {
+				return enter(newSnapshotModel(&llm.Client{Enabled: true}), "look around")
+			},
+		},
+		{
+			name: "llm_response",
+			steps: func() tea.Model {
+				m := enter(newSnapshotModel(&llm.Client{Enabled: true}), "look around")
+				m, _ = m.Update(LLMResponseMsg{RequestID: 1, Response: "Rain hammers the skylights. Somewhere deeper in the store, a shelf creaks."})
+				return m
+			},
+		},
+		{
+			name: "debug",
+			steps: func() tea.Model {
+				m := newSnapshotModel(&llm.Client{Enabled: true, ModelName: llm.DefaultModel, TokenBudget: 5000})
+				m, _ = m.Update(tea.KeyMsg{Type: tea.KeyF3})
+				return m
+			},
+		},
+		{
+			name: "llm_markdown",
+			steps: func() tea.Model {
+				m := enter(newSnapshotModel(&llm.Client{Enabled: true}), "talk brenda")
+				m, _ = m.Update(LLMResponseMsg{RequestID: 1, Response: "Brenda grips the counter. *Hard.*\n\n\"Dale was **my friend**,\" she whispers. \"He kept saying the counts were off, and nobody would listen to him, not once.\"\n\n- The register drawer hangs open\n- Gary watches from the dark"})
+				return m
+			},
+		},
+		{
+			name: "game_over",
+			steps: func() tea.Model {
+				m := newSnapshotModel(nil)
+				gs := m.(Model).GameState
+				gs.Location = game.LocLoadingDock
+				gs.Clues["door_unlocked"] = "true"
+				return enter(m, "escape")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertGolden(t, tt.name, tt.steps().View())
+		})
+	}
+}
+
+func TestEscStopsWaitingForLLM(t *testing.T) {
+	m := enter(newSnapshotModel(&llm.Client{Enabled: true}), "look around")
+	if !m.(Model).LoadingLLM {
+		t.Fatal("not waiting for the LLM after a narrated command")
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		t.Errorf("Esc while loading returned a command %T, want none", cmd())
+	}
+	if m.(Model).LoadingLLM {
+		t.Error("still loading after Esc")
+	}
+
+	// A reply that turns up afterwards is dropped
+	before := len(m.(Model).Transcript)
+	m, _ = m.Update(LLMResponseMsg{RequestID: 1, Response: "Too late."})
+	if got := len(m.(Model).Transcript); got != before {
+		t.Errorf("late reply added %d transcript entries, want 0", got-before)
+	}
+}
+
+// slowGenerator holds each reply until released, so the game can move on
+// while a call is in flight
+type slowGenerator struct {
+	release chan struct{}
+}
+
+func (g slowGenerator) Generate(ctx context.Context, prompt string) (llm.Reply, error) {
+	<-g.release
+	return llm.Reply{Text: "The aisles stay dark."}, nil
+}
+
+// TestLLMCallDoesNotRaceGame runs the LLM command on its own goroutine while
+// the player keeps playing, as Bubble Tea does. Run with -race.
+func TestLLMCallDoesNotRaceGame(t *testing.T) {
+	generator := slowGenerator{release: make(chan struct{})}
+	m := newSnapshotModel(&llm.Client{Enabled: true, Generator: generator})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("look around")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	replies := make(chan tea.Msg)
+	go func() { replies <- cmd() }()
+
+	// Keep playing while the narrator works
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	for _, command := range []string{"n", "take crumpled employee discount voucher", "take dale's handheld scanner", "e"} {
+		m = enter(m, command)
+	}
+	close(generator.release)
+
+	// The reply to the abandoned call is dropped
+	before := len(m.(Model).Transcript)
+	m, _ = m.Update(<-replies)
+	if got := len(m.(Model).Transcript); got != before {
+		t.Errorf("stale reply added %d transcript entries, want 0", got-before)
+	}
+
+	// A new call is answered normally
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("look around")})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+	transcript := m.(Model).Transcript
+	if last := transcript[len(transcript)-1]; last.Text != "The aisles stay dark." {
+		t.Errorf("last transcript entry = %q, want the narrator's reply", last.Text)
+	}
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"blackoutbargain/game"
+	"blackoutbargain/profile"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- End-of-Game Report ---
+
+// recordScore adds the finished game to the high score table and saves it
+func (m *Model) recordScore() {
+	score := m.GameState.Score()
+	m.ScoreRank = m.HighScores.Add(profile.HighScore{
+		Score:     score.Total,
+		Escaped:   m.GameState.GameOver,
+		Clues:     len(m.GameState.Evidence),
+		Turns:     m.GameState.Stats.Turns,
+		HintsUsed: m.GameState.HintsUsed(),
+		Elapsed:   m.GameState.Elapsed(),
+		Date:      game.Now(),
+	})
+	if m.HighScorePath == "" {
+		return
+	}
+	if err := m.HighScores.Save(m.HighScorePath); err != nil {
+		log.Printf("Error saving high scores: %v", err)
+	}
+}
+
+// reportView renders the ending, the score breakdown and the high score table
+func (m Model) reportView() string {
+	var s strings.Builder
+
+	s.WriteString(m.Styles.Narrator.Render(game.EscapeNarrative))
+	s.WriteString("\n\n")
+
+	// Score breakdown
+	score := m.GameState.Score()
+	s.WriteString(m.Styles.Title.Render("Score"))
+	s.WriteString("\n")
+	for _, line := range score.Lines {
+		s.WriteString(fmt.Sprintf("%-16s %-28s %s\n", line.Label, line.Detail, formatPoints(line.Points)))
+	}
+	s.WriteString(m.Styles.Location.Render(fmt.Sprintf("%-45s %5d", "Total", score.Total)))
+	s.WriteString("\n\n")
+
+	// Narrator usage, when it was used at all
+	if m.LLMClient != nil && m.LLMClient.Enabled {
+		if usage := m.LLMClient.Usage(); usage.Calls > 0 {
+			s.WriteString(m.Styles.Title.Render("Narrator"))
+			s.WriteString("\n")
+			s.WriteString(usageSummary(usage))
+			s.WriteString("\n\n")
+		}
+	}
+
+	// High scores
+	s.WriteString(m.Styles.Title.Render("High Scores"))
+	s.WriteString("\n")
+	s.WriteString(highScoreTable(m.HighScores, m.ScoreRank, m.Styles))
+	if m.ScoreRank == 0 {
+		s.WriteString("\n")
+		s.WriteString(m.Styles.Help.Render("This run didn't make the table."))
+	}
+
+	if len(m.Toasts) > 0 {
+		s.WriteString("\n\n")
+		s.WriteString(m.toastView())
+	}
+
+	s.WriteString(m.Styles.Help.Render("\n\nPress Ctrl+C or Esc to exit."))
+	return m.Styles.Base.Render(s.String()) + "\n"
+}
+
+// formatPoints shows a signed point value, right aligned
+func formatPoints(points int) string {
+	if points > 0 {
+		return fmt.Sprintf("%+5d", points)
+	}
+	return fmt.Sprintf("%5d", points)
+}
+
+// highScoreTable renders the leaderboard, highlighting the given rank
+func highScoreTable(table *profile.HighScoreTable, highlight int, styles Styles) string {
+	if len(table.Entries) == 0 {
+		return styles.Help.Render("No scores yet.")
+	}
+	var rows []string
+	for i, entry := range table.Entries {
+		row := fmt.Sprintf("%2d. %5d  %2d clues  %3d turns  %d hints  %8s  %s",
+			i+1, entry.Score, entry.Clues, entry.Turns, entry.HintsUsed, entry.Elapsed, entry.Date.Format("2006-01-02"))
+		if i+1 == highlight {
+			row = styles.MapCurrent.Render(row + "  <- you")
+		}
+		rows = append(rows, row)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -9,11 +9,39 @@ type Styles struct {
 	Base      lipgloss.Style
 	Title     lipgloss.Style
 	Location  lipgloss.Style
+	Exits     lipgloss.Style
 	Items     lipgloss.Style
 	Inventory lipgloss.Style
 	Message   lipgloss.Style
+	Narrator  lipgloss.Style
+	Story     lipgloss.Style
+	Error     lipgloss.Style
 	Help      lipgloss.Style
 	Prompt    lipgloss.Style
+
+	// Transcript
+	TranscriptInput lipgloss.Style
+
+	// Lock widgets
+	KeypadKey         lipgloss.Style
+	KeypadKeySelected lipgloss.Style
+	KeypadDisplay     lipgloss.Style
+
+	// Clue board
+	BoardPanel    lipgloss.Style
+	BoardSelected lipgloss.Style
+
+	// Map panel
+	MapPanel   lipgloss.Style
+	MapCurrent lipgloss.Style
+	MapVisited lipgloss.Style
+	MapKnown   lipgloss.Style
+
+	// Notifications
+	Toast lipgloss.Style
+
+	// Debug overlay
+	Debug lipgloss.Style
 }
 
 // NewStyles creates a new set of styles with default values
@@ -23,10 +51,27 @@ func NewStyles() Styles {
 	s.Base = lipgloss.NewStyle().Padding(0, 1)                                                // Basic padding
 	s.Title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63")).MarginBottom(1) // Purple, spacing
 	s.Location = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("86"))              // Light Blue/Cyan
+	s.Exits = lipgloss.NewStyle().Foreground(lipgloss.Color("109"))                           // Muted teal
 	s.Items = lipgloss.NewStyle().Foreground(lipgloss.Color("75"))                            // Cyan
 	s.Inventory = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))                       // Orange
-	s.Message = lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Italic(true)            // Light Gray Italic
+	s.Message = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))                         // Light Gray
+	s.Narrator = lipgloss.NewStyle().Foreground(lipgloss.Color("187")).Italic(true)           // Pale yellow italic
+	s.Story = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("230"))                // Cream, stands out from feedback
+	s.Error = lipgloss.NewStyle().Foreground(lipgloss.Color("174"))                           // Muted red
 	s.Help = lipgloss.NewStyle().Foreground(lipgloss.Color("242"))                            // Dark Gray
 	s.Prompt = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))               // White prompt
+	s.TranscriptInput = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("255"))      // Matches the prompt
+	s.KeypadKey = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("240")).Width(5).Align(lipgloss.Center)
+	s.KeypadKeySelected = s.KeypadKey.BorderForeground(lipgloss.Color("220")).Foreground(lipgloss.Color("220")).Bold(true)
+	s.KeypadDisplay = lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Background(lipgloss.Color("235")).Padding(0, 1) // LCD green
+	s.BoardPanel = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1)
+	s.BoardSelected = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220")) // Highlighted row
+	s.MapPanel = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")).Padding(0, 1)
+	s.MapCurrent = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("220")) // Yellow, you are here
+	s.MapVisited = lipgloss.NewStyle().Foreground(lipgloss.Color("86"))             // Same cyan as locations
+	s.MapKnown = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))              // Dim gray, unexplored
+
+	s.Toast = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("0")).Background(lipgloss.Color("141")).Padding(0, 1) // Lavender banner
+	s.Debug = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(lipgloss.Color("240")).Foreground(lipgloss.Color("245")).Padding(0, 1)
 	return s
 }
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"blackoutbargain/game"
+	"blackoutbargain/profile"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Title Screen ---
+
+// titleMenu lists the title screen options in order
+var titleMenu = []string{"New Game", "Achievements", "Quit"}
+
+// updateTitle handles keys on the title screen and the achievements list
+// opened from it
+func (m Model) updateTitle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.ShowingAchievements {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "enter", "q":
+			m.ShowingAchievements = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "esc", "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.TitleCursor = max(m.TitleCursor-1, 0)
+	case "down", "j":
+		m.TitleCursor = min(m.TitleCursor+1, len(titleMenu)-1)
+	case "enter", " ":
+		switch titleMenu[m.TitleCursor] {
+		case "New Game":
+			m.ShowingTitle = false
+		case "Achievements":
+			m.ShowingAchievements = true
+		case "Quit":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// titleView renders the title and the menu
+func (m Model) titleView() string {
+	if m.ShowingAchievements {
+		return m.achievementsView()
+	}
+
+	items := make([]string, 0, len(titleMenu))
+	for i, item := range titleMenu {
+		if i == m.TitleCursor {
+			items = append(items, m.Styles.BoardSelected.Render("> "+item+" "))
+		} else {
+			items = append(items, m.Styles.Items.Render("  "+item))
+		}
+	}
+
+	unlocked := 0
+	for _, a := range profile.AchievementList {
+		if m.Achievements.IsUnlocked(a.ID) {
+			unlocked++
+		}
+	}
+
+	// The menu lines share a left edge; the block itself is centered
+	screen := lipgloss.JoinVertical(lipgloss.Center,
+		m.Styles.Title.Render("--- Blackout Bargain ---"),
+		m.Styles.Narrator.Render("A storm, a blackout, and a body in aisle 13."),
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, items...),
+		"",
+		m.Styles.Help.Render(fmt.Sprintf("%d of %d achievements unlocked", unlocked, len(profile.AchievementList))),
+		"",
+		m.Styles.Help.Render("Up/Down to choose, Enter to select, Esc to quit."),
+	)
+	return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center, screen)
+}
+
+// achievementsView lists every achievement and whether it has been unlocked
+func (m Model) achievementsView() string {
+	var s strings.Builder
+	title := m.Styles.Title.Render("--- Achievements ---")
+	s.WriteString(lipgloss.PlaceHorizontal(m.Width, lipgloss.Center, title))
+	s.WriteString("\n")
+
+	var list strings.Builder
+	for _, a := range profile.AchievementList {
+		if when, ok := m.Achievements.Unlocked[a.ID]; ok {
+			list.WriteString(m.Styles.MapCurrent.Render("[x] " + a.Title))
+			list.WriteString(m.Styles.Help.Render("  unlocked " + when.Format("2006-01-02")))
+		} else {
+			list.WriteString(m.Styles.MapKnown.Render("[ ] " + a.Title))
+		}
+		list.WriteString("\n    ")
+		list.WriteString(m.Styles.Message.Render(a.Description))
+		list.WriteString("\n")
+	}
+	list.WriteString("\n")
+	list.WriteString(m.Styles.Help.Render(fmt.Sprintf("Endings seen: %d of %d", len(m.Achievements.Endings), len(game.Endings))))
+	s.WriteString(m.Styles.Base.Render(list.String()))
+
+	s.WriteString("\n\n")
+	s.WriteString(m.Styles.Help.Render(" Esc or Enter to go back."))
+	return s.String()
+}
+
+// checkAchievements unlocks any achievements the game now satisfies, saves
+// them and returns the commands for their toasts
+func (m *Model) checkAchievements() tea.Cmd {
+	unlocked := m.Achievements.Evaluate(m.GameState)
+	if len(unlocked) == 0 {
+		return nil
+	}
+	if m.AchievementsPath != "" {
+		if err := m.Achievements.Save(m.AchievementsPath); err != nil {
+			log.Printf("Error saving achievements: %v", err)
+		}
+	}
+
+	cmds := make([]tea.Cmd, 0, len(unlocked))
+	for _, a := range unlocked {
+		cmds = append(cmds, m.pushToast("Achievement unlocked: "+a.Title))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- Toast Notifications ---
+
+// toastDuration is how long a toast stays on screen
+const toastDuration = 4 * time.Second
+
+// Toast is a short-lived notification shown above the footer
+type Toast struct {
+	ID   int
+	Text string
+}
+
+// toastExpiredMsg removes a toast once its time is up
+type toastExpiredMsg struct {
+	ID int
+}
+
+// pushToast shows a notification and returns the command that expires it
+func (m *Model) pushToast(text string) tea.Cmd {
+	m.nextToastID++
+	id := m.nextToastID
+	m.Toasts = append(m.Toasts, Toast{ID: id, Text: text})
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{ID: id}
+	})
+}
+
+// dismissToast removes the toast with the given ID
+func (m *Model) dismissToast(id int) {
+	for i, toast := range m.Toasts {
+		if toast.ID == id {
+			m.Toasts = append(m.Toasts[:i:i], m.Toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// toastView renders the active toasts, one per line
+func (m Model) toastView() string {
+	lines := make([]string, 0, len(m.Toasts))
+	for _, toast := range m.Toasts {
+		lines = append(lines, m.Styles.Toast.Render(toast.Text))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"strings"
+
+	"blackoutbargain/game"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- Scrollback Transcript ---
+
+// EntryKind distinguishes who produced a line in the transcript
+type EntryKind int
+
+const (
+	EntryInput    EntryKind = iota // Command typed by the player
+	EntryEngine                    // Feedback from the Go game logic
+	EntryStory                     // Story beats from the Go game logic
+	EntryError                     // Commands the game couldn't carry out
+	EntryNarrator                  // Narration from the LLM
+)
+
+// TranscriptEntry is a single command or response in the scrollback
+type TranscriptEntry struct {
+	Kind EntryKind
+	Text string
+}
+
+// addEntry appends text to the transcript and scrolls back to the latest entry
+func (m *Model) addEntry(kind EntryKind, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	m.Transcript = append(m.Transcript, TranscriptEntry{Kind: kind, Text: text})
+	m.FollowTranscript = true
+}
+
+// addResult appends each message from a command's result to the transcript
+func (m *Model) addResult(result game.Result) {
+	for _, msg := range result.Messages {
+		switch msg.Kind {
+		case game.MessageStory:
+			m.addEntry(EntryStory, msg.Text)
+		case game.MessageError:
+			m.addEntry(EntryError, msg.Text)
+		default:
+			m.addEntry(EntryEngine, msg.Text)
+		}
+	}
+}
+
+// renderTranscript styles every entry, wrapped to the given width
+func (m Model) renderTranscript(width int) string {
+	var sb strings.Builder
+	for i, entry := range m.Transcript {
+		if i > 0 {
+			sb.WriteString("\n")
+			if entry.Kind == EntryInput {
+				sb.WriteString("\n") // Blank line before each new turn
+			}
+		}
+		switch entry.Kind {
+		case EntryInput:
+			sb.WriteString(m.Styles.TranscriptInput.Width(width).Render("> " + entry.Text))
+		case EntryNarrator:
+			sb.WriteString(m.renderNarration(entry.Text, width))
+		case EntryStory:
+			sb.WriteString(m.Styles.Story.Width(width).Render(entry.Text))
+		case EntryError:
+			sb.WriteString(m.Styles.Error.Width(width).Render(entry.Text))
+		default:
+			sb.WriteString(m.Styles.Message.Width(width).Render(entry.Text))
+		}
+	}
+	return sb.String()
+}
+
+// transcriptViewport returns the viewport sized to the given area and filled
+// with the current transcript, pinned to the bottom unless the player scrolled up
+func (m Model) transcriptViewport(width, height int) viewport.Model {
+	vp := m.Viewport
+	vp.Width = width
+	vp.Height = max(height, 1)
+	vp.SetContent(m.renderTranscript(width))
+	if m.FollowTranscript {
+		vp.GotoBottom()
+	}
+	return vp
+}
+
+// scrollTranscript applies paging keys and mouse wheel events to the transcript.
+// It reports whether the message was consumed.
+func (m *Model) scrollTranscript(msg tea.Msg) (bool, tea.Cmd) {
+	width, height := m.transcriptSize()
+	vp := m.transcriptViewport(width, height)
+
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyPgUp:
+			vp.PageUp()
+		case tea.KeyPgDown:
+			vp.PageDown()
+		default:
+			return false, nil
+		}
+	case tea.MouseMsg:
+		if msg.Button != tea.MouseButtonWheelUp && msg.Button != tea.MouseButtonWheelDown {
+			return false, nil
+		}
+		vp, cmd = vp.Update(msg)
+	default:
+		return false, nil
+	}
+
+	m.Viewport = vp
+	m.FollowTranscript = vp.AtBottom()
+	return true, cmd
+}